@@ -1,93 +1,219 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 type SpeedrunAPI struct {
-	client    *http.Client
-	userCache map[string]*User
-	cacheMux  sync.RWMutex
+	client      *http.Client
+	baseURL     string
+	apiKey      string
+	timeout     time.Duration
+	maxRetries  int
+	backoffBase int
+	cache       *responseCache
+	limiter     *rateLimiter
+	userCache   map[string]*User
+	cacheMux    sync.RWMutex
+	
+	// offline answers GETs instead of the network; recorder sees every
+	// response while 'sync' runs (see snapshot.go)
+	offline  *offlineStore
+	recorder *snapshotRecorder
+	
+	// unreadMux guards the notification badge, which is refreshed in the
+	// background
+	unreadMux      sync.Mutex
+	unreadCount    int
+	unreadChecked  time.Time
+	unreadChecking bool
 }
 
 func NewSpeedrunAPI() *SpeedrunAPI {
-	return &SpeedrunAPI{
+	api := &SpeedrunAPI{
 		client: &http.Client{
 			Timeout: 0, // No timeout on client, we'll handle it with context
 		},
-		userCache: make(map[string]*User),
+		baseURL:     strings.TrimSuffix(settings.String("api_base"), "/"),
+		apiKey:      loadAPIKey(),
+		timeout:     settings.Duration("timeout"),
+		maxRetries:  settings.Int("max_retries"),
+		backoffBase: settings.Int("backoff_base"),
+		cache:       newResponseCache(settings),
+		limiter:     newRateLimiter(settings.Int("rate_limit")),
+		userCache:   make(map[string]*User),
+	}
+	
+	// Fixtures must see every request, so the response cache is bypassed.
+	// Snapshots are already local, so offline mode skips it too.
+	switch {
+	case settings.Bool("offline"):
+		api.offline = offlineSnapshots()
+		api.cache = nil
+	case globalOptions.Record != "":
+		api.client.Transport = newRecordingTransport(globalOptions.Record, http.DefaultTransport)
+		api.cache = nil
+	case globalOptions.Replay != "":
+		api.client.Transport = newReplayTransport(globalOptions.Replay)
+		api.cache = nil
+		api.backoffBase = 0
+		api.limiter = nil
 	}
+	return api
 }
 
+func (api *SpeedrunAPI) HasAPIKey() bool {
+	return api.apiKey != ""
+}
+
+// makeRequest performs a GET, answering from the on-disk cache when the
+// endpoint's TTL allows.
 func (api *SpeedrunAPI) makeRequest(endpoint string) ([]byte, error) {
-	return api.makeRequestWithRetry(endpoint, MaxRetries)
+	if api.offline != nil {
+		body, err := api.offline.get(endpoint)
+		status := http.StatusOK
+		if err != nil {
+			status = http.StatusNotFound
+		}
+		logRequest("GET", endpoint, 0, status, len(body), 0, "offline", err)
+		return body, err
+	}
+	
+	if !api.cache.enabled(endpoint) {
+		body, err := api.makeRequestWithRetry("GET", endpoint, nil, api.maxRetries, "off")
+		if err == nil {
+			api.recorder.record(endpoint, body)
+		}
+		return body, err
+	}
+	
+	if body, ok := api.cache.get(endpoint); ok {
+		logRequest("GET", endpoint, 0, http.StatusOK, len(body), 0, "hit", nil)
+		return body, nil
+	}
+	
+	body, err := api.makeRequestWithRetry("GET", endpoint, nil, api.maxRetries, "miss")
+	if err == nil {
+		api.cache.put(endpoint, body)
+	}
+	return body, err
+}
+
+// InvalidateCache drops cached responses under an endpoint path, so the
+// next request for it goes to the network.
+func (api *SpeedrunAPI) InvalidateCache(endpointPath string) {
+	api.cache.invalidate(endpointPath)
+}
+
+func (api *SpeedrunAPI) makeRequestWithBody(method, endpoint string, payload []byte) ([]byte, error) {
+	if api.offline != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("%s requests need the network and cannot be sent offline", method),
+			URL:     endpoint,
+			Context: "offline",
+		}
+	}
+	return api.makeRequestWithRetry(method, endpoint, payload, api.maxRetries, "off")
 }
 
-func (api *SpeedrunAPI) makeRequestWithRetry(endpoint string, retries int) ([]byte, error) {
+// makeRequestWithRetry sends a request, retrying rate limits and server
+// errors. cacheStatus is only recorded in the request log.
+func (api *SpeedrunAPI) makeRequestWithRetry(method, endpoint string, payload []byte, retries int, cacheStatus string) ([]byte, error) {
 	var lastErr error
 	
+	// A failed POST may still have been applied, so only retry it on 429
+	idempotent := method != "POST"
+	
 	for attempt := 0; attempt <= retries; attempt++ {
 		if attempt > 0 {
-			backoffDuration := time.Duration(BackoffBase<<(attempt-1)) * time.Second
-			debugLog("Retrying request to %s after %v (attempt %d/%d)", endpoint, backoffDuration, attempt+1, retries+1)
+			backoffDuration := time.Duration(api.backoffBase<<(attempt-1)) * time.Second
+			logger.Debug("retrying request", "endpoint", endpoint, "backoff", backoffDuration, "attempt", attempt+1, "max_attempts", retries+1)
 			time.Sleep(backoffDuration)
 		}
+		api.limiter.wait()
+		
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
 		
-		req, err := http.NewRequest("GET", APIBase+endpoint, nil)
+		req, err := http.NewRequest(method, api.baseURL+endpoint, reqBody)
 		if err != nil {
 			return nil, &APIError{
 				Message:    fmt.Sprintf("failed to create request: %v", err),
 				StatusCode: 0,
-				URL:        APIBase + endpoint,
+				URL:        api.baseURL + endpoint,
 				Context:    "request creation",
 			}
 		}
 		
 		req.Header.Set("User-Agent", UserAgent)
+		if api.apiKey != "" {
+			req.Header.Set("X-API-Key", api.apiKey)
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
 		
-		ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout*time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		if api.timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), api.timeout)
+		}
 		defer cancel()
 		req = req.WithContext(ctx)
 		
+		traceRequest(req, payload)
+		start := time.Now()
 		resp, err := api.client.Do(req)
 		
 		if err != nil {
 			lastErr = &APIError{
 				Message:    fmt.Sprintf("request failed: %v", err),
 				StatusCode: 0,
-				URL:        APIBase + endpoint,
+				URL:        api.baseURL + endpoint,
 				Context:    "network error",
 			}
+			logRequest(method, endpoint, attempt+1, 0, 0, time.Since(start), cacheStatus, lastErr)
+			if !idempotent {
+				return nil, lastErr
+			}
 			continue
 		}
 		
-		if resp.StatusCode == 429 && attempt < retries {
-			resp.Body.Close()
-			debugLog("Rate limited (429), retrying...")
-			continue
-		}
-		
-		if resp.StatusCode >= 500 && attempt < retries {
+		if (resp.StatusCode == 429 || (resp.StatusCode >= 500 && idempotent)) && attempt < retries {
 			resp.Body.Close()
-			debugLog("Server error (%d), retrying...", resp.StatusCode)
+			traceResponse(endpoint, resp, nil)
+			logRequest(method, endpoint, attempt+1, resp.StatusCode, 0, time.Since(start), cacheStatus, fmt.Errorf("status %d, retrying", resp.StatusCode))
 			continue
 		}
 		
-		if resp.StatusCode != 200 {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			errorBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
 			resp.Body.Close()
-			return nil, &APIError{
-				Message:    fmt.Sprintf("API request failed with status %d", resp.StatusCode),
+			traceResponse(endpoint, resp, errorBody)
+			
+			message := fmt.Sprintf("API request failed with status %d", resp.StatusCode)
+			if detail := readErrorMessage(bytes.NewReader(errorBody)); detail != "" {
+				message += ": " + detail
+			}
+			apiErr := &APIError{
+				Message:    message,
 				StatusCode: resp.StatusCode,
-				URL:        APIBase + endpoint,
+				URL:        api.baseURL + endpoint,
 				Context:    "API response",
 			}
+			logRequest(method, endpoint, attempt+1, resp.StatusCode, len(errorBody), time.Since(start), cacheStatus, apiErr)
+			return nil, apiErr
 		}
 		
 		// Read the response body before the context expires
@@ -99,22 +225,38 @@ func (api *SpeedrunAPI) makeRequestWithRetry(endpoint string, retries int) ([]by
 				Message: fmt.Sprintf("failed to read response body: %v", err),
 				Context: "response reading",
 			}
+			logRequest(method, endpoint, attempt+1, resp.StatusCode, len(body), time.Since(start), cacheStatus, lastErr)
 			continue
 		}
 		
+		traceResponse(endpoint, resp, body)
+		logRequest(method, endpoint, attempt+1, resp.StatusCode, len(body), time.Since(start), cacheStatus, nil)
 		return body, nil
 	}
 	
 	return nil, lastErr
 }
 
+// readErrorMessage extracts the "message" field speedrun.com includes in
+// error responses, e.g. when a moderator action is not permitted.
+func readErrorMessage(body io.Reader) string {
+	var errResp struct {
+		Message string `json:"message"`
+	}
+	data, err := io.ReadAll(io.LimitReader(body, 64*1024))
+	if err != nil || json.Unmarshal(data, &errResp) != nil {
+		return ""
+	}
+	return errResp.Message
+}
+
 func (api *SpeedrunAPI) SearchGames(query string) ([]Game, error) {
-	debugLog("Searching for games with query: %s", query)
+	logger.Debug("searching games", "query", query)
 	
 	encodedQuery := url.QueryEscape(query)
-	fmt.Print("🔍 Searching for games...")
+	showProgress("🔍", "Searching for games...")
 	body, err := api.makeRequest(fmt.Sprintf("/games?name=%s&max=20&embed=categories", encodedQuery))
-	fmt.Print("\r                         \r") // Clear the loading message
+	clearProgress() // Clear the loading message
 	
 	if err != nil {
 		return nil, err
@@ -136,12 +278,12 @@ func (api *SpeedrunAPI) SearchGames(query string) ([]Game, error) {
 		}
 	}
 
-	debugLog("Found %d games", len(games))
+	logger.Debug("found games", "count", len(games))
 	return games, nil
 }
 
 func (api *SpeedrunAPI) GetGameCategories(gameID string) ([]Category, error) {
-	debugLog("Fetching categories for game: %s", gameID)
+	logger.Debug("fetching categories", "game", gameID)
 	
 	body, err := api.makeRequest(fmt.Sprintf("/games/%s/categories", gameID))
 	if err != nil {
@@ -164,12 +306,12 @@ func (api *SpeedrunAPI) GetGameCategories(gameID string) ([]Category, error) {
 		}
 	}
 
-	debugLog("Found %d categories", len(categories))
+	logger.Debug("found categories", "count", len(categories))
 	return categories, nil
 }
 
 func (api *SpeedrunAPI) GetGamePlatforms(gameID string) ([]Platform, error) {
-	debugLog("Fetching platforms for game: %s", gameID)
+	logger.Debug("fetching platforms", "game", gameID)
 	
 	body, err := api.makeRequest(fmt.Sprintf("/games/%s?embed=platforms", gameID))
 	if err != nil {
@@ -191,7 +333,7 @@ func (api *SpeedrunAPI) GetGamePlatforms(gameID string) ([]Platform, error) {
 		}
 	}
 
-	debugLog("Found %d platforms", len(apiResp.Data.Platforms.Data))
+	logger.Debug("found platforms", "count", len(apiResp.Data.Platforms.Data))
 	return apiResp.Data.Platforms.Data, nil
 }
 
@@ -199,7 +341,7 @@ func (api *SpeedrunAPI) CheckPlatformForCategory(gameID, categoryID, platformID
 	endpoint := fmt.Sprintf("/leaderboards/%s/category/%s?platform=%s", gameID, categoryID, platformID)
 	_, err := api.makeRequest(endpoint)
 	if err != nil {
-		debugLog("Platform check failed for %s/%s/%s: %v", gameID, categoryID, platformID, err)
+		logger.Warn("platform check failed", "game", gameID, "category", categoryID, "platform", platformID, "error", err)
 		return false
 	}
 	
@@ -207,7 +349,7 @@ func (api *SpeedrunAPI) CheckPlatformForCategory(gameID, categoryID, platformID
 }
 
 func (api *SpeedrunAPI) GetPlatformsForCategory(gameID, categoryID string) ([]Platform, error) {
-	debugLog("Fetching platforms for category: %s/%s", gameID, categoryID)
+	logger.Debug("fetching category platforms", "game", gameID, "category", categoryID)
 	
 	allPlatforms, err := api.GetGamePlatforms(gameID)
 	if err != nil {
@@ -220,7 +362,7 @@ func (api *SpeedrunAPI) GetPlatformsForCategory(gameID, categoryID string) ([]Pl
 		valid    bool
 	}, len(allPlatforms))
 
-	fmt.Printf("🔍 Checking %d platforms...", len(allPlatforms))
+	showProgress("🔍", "Checking %d platforms...", len(allPlatforms))
 	
 	for _, platform := range allPlatforms {
 		go func(p Platform) {
@@ -239,14 +381,14 @@ func (api *SpeedrunAPI) GetPlatformsForCategory(gameID, categoryID string) ([]Pl
 		}
 	}
 	
-	fmt.Print("\r                                \r") // Clear the checking message
+	clearProgress() // Clear the checking message
 
-	debugLog("Found %d valid platforms for category", len(validPlatforms))
+	logger.Debug("found category platforms", "count", len(validPlatforms))
 	return validPlatforms, nil
 }
 
-func (api *SpeedrunAPI) GetCategoryVariables(categoryID string) ([]SubCategory, error) {
-	debugLog("Fetching variables for category: %s", categoryID)
+func (api *SpeedrunAPI) GetVariables(categoryID string) ([]Variable, error) {
+	logger.Debug("fetching variables", "category", categoryID)
 	
 	body, err := api.makeRequest(fmt.Sprintf("/categories/%s/variables", categoryID))
 	if err != nil {
@@ -269,6 +411,15 @@ func (api *SpeedrunAPI) GetCategoryVariables(categoryID string) ([]SubCategory,
 		}
 	}
 
+	return variables, nil
+}
+
+func (api *SpeedrunAPI) GetCategoryVariables(categoryID string) ([]SubCategory, error) {
+	variables, err := api.GetVariables(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
 	var subCategories []SubCategory
 	for _, variable := range variables {
 		if variable.IsSubcategory {
@@ -282,7 +433,7 @@ func (api *SpeedrunAPI) GetCategoryVariables(categoryID string) ([]SubCategory,
 		}
 	}
 
-	debugLog("Found %d subcategories", len(subCategories))
+	logger.Debug("found subcategories", "count", len(subCategories))
 	return subCategories, nil
 }
 
@@ -310,50 +461,109 @@ func (api *SpeedrunAPI) GetVariableIDForCategory(categoryID string) string {
 	return ""
 }
 
-func (api *SpeedrunAPI) GetLeaderboard(gameID, categoryID, platformID, variableID string) (*Leaderboard, error) {
-	debugLog("Fetching leaderboard for %s/%s (platform: %s, variable: %s)", gameID, categoryID, platformID, variableID)
+// GetLeaderboard fetches a category's board, optionally narrowed to a
+// platform and subcategory value. A non-empty date (YYYY-MM-DD) returns the
+// board as it stood on that day.
+func (api *SpeedrunAPI) GetLeaderboard(gameID, categoryID, platformID, variableID, date string) (*Leaderboard, error) {
+	logger.Debug("fetching leaderboard", "game", gameID, "category", categoryID, "platform", platformID, "variable", variableID, "date", date)
 	
 	var endpoint string
 	queryParams := "embed=game,category,platforms" // Remove players and regions embed for performance
+	filterParams := ""
 	
 	if platformID != "" {
-		queryParams += "&platform=" + platformID
+		filterParams += "&platform=" + platformID
 	}
 	
 	if variableID != "" {
 		varID := api.GetVariableIDForCategory(categoryID)
 		if varID != "" {
-			queryParams += "&var-" + varID + "=" + variableID
+			filterParams += "&var-" + varID + "=" + variableID
 		}
 	}
 	
-	endpoint = fmt.Sprintf("/leaderboards/%s/category/%s?%s", gameID, categoryID, queryParams)
+	if date != "" {
+		filterParams += "&date=" + url.QueryEscape(date)
+	}
+	
+	endpoint = fmt.Sprintf("/leaderboards/%s/category/%s?%s%s", gameID, categoryID, queryParams, filterParams)
 	
 	// Show progress for potentially slow leaderboard requests
-	fmt.Print("⏳ Loading leaderboard data...")
+	showProgress("⏳", "Loading leaderboard data...")
 	body, err := api.makeRequest(endpoint)
-	fmt.Print("\r                              \r") // Clear the loading message
+	clearProgress() // Clear the loading message
 	
 	if err != nil {
 		return nil, err
 	}
 
+	leaderboard, err := parseLeaderboard(body)
+	if err != nil {
+		return nil, err
+	}
+	leaderboard.source = fmt.Sprintf("/leaderboards/%s/category/%s?embed=players%s", gameID, categoryID, filterParams)
+	leaderboard.AsOf = date
+
+	logger.Debug("fetched leaderboard", "runs", len(leaderboard.Runs))
+	return leaderboard, nil
+}
+
+// LoadPlayerNames fills lb.PlayerMap from the players embed, which
+// GetLeaderboard skips for speed. Searching by runner needs every name.
+func (api *SpeedrunAPI) LoadPlayerNames(lb *Leaderboard) error {
+	if lb.PlayerMap != nil || lb.source == "" {
+		return nil
+	}
+	
+	showProgress("⏳", "Loading runner names...")
+	body, err := api.makeRequest(lb.source)
+	clearProgress()
+	
+	if err != nil {
+		return err
+	}
+
+	var apiResp struct {
+		Data struct {
+			Players struct {
+				Data []User `json:"data"`
+			} `json:"players"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	lb.PlayerMap = make(map[string]string)
+	for _, player := range apiResp.Data.Players.Data {
+		if player.ID != "" {
+			lb.PlayerMap[player.ID] = player.Names.International
+		}
+	}
+
+	logger.Debug("loaded runner names", "count", len(lb.PlayerMap))
+	return nil
+}
+
+func parseLeaderboard(body []byte) (*Leaderboard, error) {
 	var apiResp struct {
 		Data struct {
 			Weblink string `json:"weblink"`
-			Game    struct {
-				Data Game `json:"data"`
-			} `json:"game"`
-			Category struct {
-				Data Category `json:"data"`
-			} `json:"category"`
-			Runs []struct {
+			Game     json.RawMessage `json:"game"`
+			Category json.RawMessage `json:"category"`
+			Runs     []struct {
 				Place int `json:"place"`
 				Run   Run `json:"run"`
 			} `json:"runs"`
 			Platforms struct {
 				Data []Platform `json:"data"`
 			} `json:"platforms"`
+			Players struct {
+				Data []User `json:"data"`
+			} `json:"players"`
 		} `json:"data"`
 	}
 	
@@ -369,56 +579,162 @@ func (api *SpeedrunAPI) GetLeaderboard(gameID, categoryID, platformID, variableI
 		platformMap[platform.ID] = platform.Name
 	}
 
-	leaderboard := &Leaderboard{
+	lb := &Leaderboard{
 		Weblink:     apiResp.Data.Weblink,
-		Game:        apiResp.Data.Game,
-		Category:    apiResp.Data.Category,
 		Runs:        apiResp.Data.Runs,
 		PlatformMap: platformMap,
 	}
-
-	debugLog("Fetched leaderboard with %d runs", len(leaderboard.Runs))
-	return leaderboard, nil
+	
+	// PlayerMap stays nil without the players embed so LoadPlayerNames
+	// knows to fetch it
+	if len(apiResp.Data.Players.Data) > 0 {
+		lb.PlayerMap = make(map[string]string)
+		for _, player := range apiResp.Data.Players.Data {
+			if player.ID != "" {
+				lb.PlayerMap[player.ID] = player.Names.International
+			}
+		}
+	}
+	
+	// Without embed=game,category the API sends bare IDs instead of objects.
+	if err := unmarshalEmbedded(apiResp.Data.Game, &lb.Game); err != nil {
+		return nil, &APIError{Message: fmt.Sprintf("failed to parse game: %v", err), Context: "JSON parsing"}
+	}
+	if err := unmarshalEmbedded(apiResp.Data.Category, &lb.Category); err != nil {
+		return nil, &APIError{Message: fmt.Sprintf("failed to parse category: %v", err), Context: "JSON parsing"}
+	}
+	return lb, nil
 }
 
-func (api *SpeedrunAPI) GetUserData(userID string) *User {
-	api.cacheMux.RLock()
-	if user, exists := api.userCache[userID]; exists {
-		api.cacheMux.RUnlock()
-		return user
+// unmarshalEmbeddedData decodes the object inside an embedded
+// {"data": ...} resource. Runs without a level or platform embed
+// {"data": []}, which leaves target empty.
+func unmarshalEmbeddedData(raw json.RawMessage, target interface{}) error {
+	var embedded struct {
+		Data json.RawMessage `json:"data"`
 	}
-	api.cacheMux.RUnlock()
-
-	body, err := api.makeRequest(fmt.Sprintf("/users/%s", userID))
-	if err != nil {
-		debugLog("Failed to fetch user data for %s: %v", userID, err)
-		return nil
+	if err := unmarshalEmbedded(raw, &embedded); err != nil {
+		return err
 	}
-
-	var response struct {
-		Data User `json:"data"`
+	if len(embedded.Data) == 0 || embedded.Data[0] != '{' {
+		return nil
 	}
+	return json.Unmarshal(embedded.Data, target)
+}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		debugLog("Failed to decode user data for %s: %v", userID, err)
+// unmarshalEmbedded decodes an embedded {"data": ...} resource, leaving the
+// target empty when the field holds an ID string or is absent.
+func unmarshalEmbedded(raw json.RawMessage, target interface{}) error {
+	if len(raw) == 0 || raw[0] != '{' {
 		return nil
 	}
+	return json.Unmarshal(raw, target)
+}
 
-	api.cacheMux.Lock()
-	api.userCache[userID] = &response.Data
-	api.cacheMux.Unlock()
+// GetRunLeaderboard fetches the board a run competes on: its category, its
+// level for individual-level runs, and only the subcategory variable values.
+func (api *SpeedrunAPI) GetRunLeaderboard(run Run, subcategoryVars map[string]bool) (*Leaderboard, error) {
+	params := url.Values{}
+	for varID, valueID := range run.Values {
+		if subcategoryVars[varID] {
+			params.Set("var-"+varID, valueID)
+		}
+	}
+	
+	endpoint := fmt.Sprintf("/leaderboards/%s/category/%s", run.Game, run.Category)
+	if run.Level != "" {
+		endpoint = fmt.Sprintf("/leaderboards/%s/level/%s/%s", run.Game, run.Level, run.Category)
+	}
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	
+	body, err := api.makeRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	
+	return parseLeaderboard(body)
+}
 
-	return &response.Data
+// GetBoard fetches a full-game leaderboard filtered by variable values
+// (variable ID to value ID), with game, category, platform, and player
+// names embedded.
+func (api *SpeedrunAPI) GetBoard(gameID, categoryID string, values map[string]string) (*Leaderboard, error) {
+	logger.Debug("fetching board", "game", gameID, "category", categoryID, "values", values)
+	
+	params := url.Values{}
+	params.Set("embed", "game,category,platforms,players")
+	for varID, valueID := range values {
+		params.Set("var-"+varID, valueID)
+	}
+	
+	body, err := api.makeRequest(fmt.Sprintf("/leaderboards/%s/category/%s?%s", url.PathEscape(gameID), url.PathEscape(categoryID), params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	
+	return parseLeaderboard(body)
 }
 
-func (api *SpeedrunAPI) SearchUsers(query string) ([]User, error) {
-	debugLog("Searching for users with query: %s", query)
+// GetUserPersonalBests fetches a user's best run on every leaderboard they
+// have a verified run on, with the game, category, and level embedded.
+func (api *SpeedrunAPI) GetUserPersonalBests(userID string) ([]PersonalBest, error) {
+	logger.Debug("fetching personal bests", "user", userID)
 	
-	encodedQuery := url.QueryEscape(query)
-	fmt.Print("🔍 Searching for users...")
-	body, err := api.makeRequest(fmt.Sprintf("/users?lookup=%s&max=20", encodedQuery))
-	fmt.Print("\r                         \r")
+	body, err := api.makeRequest(fmt.Sprintf("/users/%s/personal-bests?embed=game,category,level,platform", url.PathEscape(userID)))
+	if err != nil {
+		return nil, err
+	}
+	
+	var apiResp struct {
+		Data []struct {
+			Place    int             `json:"place"`
+			Run      Run             `json:"run"`
+			Game     json.RawMessage `json:"game"`
+			Category json.RawMessage `json:"category"`
+			Level    json.RawMessage `json:"level"`
+			Platform json.RawMessage `json:"platform"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+	
+	pbs := make([]PersonalBest, 0, len(apiResp.Data))
+	for _, entry := range apiResp.Data {
+		pb := PersonalBest{Place: entry.Place, Run: entry.Run}
+		var level Level
+		var platform Platform
+		for _, embed := range []struct {
+			raw    json.RawMessage
+			target interface{}
+		}{{entry.Game, &pb.Game}, {entry.Category, &pb.Category}, {entry.Level, &level}, {entry.Platform, &platform}} {
+			if err := unmarshalEmbeddedData(embed.raw, embed.target); err != nil {
+				return nil, &APIError{
+					Message: fmt.Sprintf("failed to parse personal best: %v", err),
+					Context: "JSON parsing",
+				}
+			}
+		}
+		if level.ID != "" {
+			pb.Level = &level
+		}
+		pb.Platform = platform.Name
+		pbs = append(pbs, pb)
+	}
 	
+	logger.Debug("found personal bests", "count", len(pbs))
+	return pbs, nil
+}
+
+func (api *SpeedrunAPI) GetGameLevels(gameID string) ([]Level, error) {
+	logger.Debug("fetching levels", "game", gameID)
+	
+	body, err := api.makeRequest(fmt.Sprintf("/games/%s/levels", gameID))
 	if err != nil {
 		return nil, err
 	}
@@ -431,92 +747,365 @@ func (api *SpeedrunAPI) SearchUsers(query string) ([]User, error) {
 		}
 	}
 
-	var users []User
-	if err := json.Unmarshal(apiResp.Data, &users); err != nil {
+	var levels []Level
+	if err := json.Unmarshal(apiResp.Data, &levels); err != nil {
 		return nil, &APIError{
-			Message: fmt.Sprintf("failed to parse users data: %v", err),
-			Context: "users data parsing",
+			Message: fmt.Sprintf("failed to parse levels data: %v", err),
+			Context: "levels data parsing",
 		}
 	}
 
-	debugLog("Found %d users", len(users))
-	return users, nil
+	logger.Debug("found levels", "count", len(levels))
+	return levels, nil
 }
 
-func (api *SpeedrunAPI) GetUserRuns(userID string) ([]UserRun, error) {
-	debugLog("Fetching runs for user: %s", userID)
+// GetGameQueue returns the game's unverified (status=new) runs, oldest
+// first, following the pagination links through the whole queue.
+func (api *SpeedrunAPI) GetGameQueue(gameID string) ([]Run, error) {
+	logger.Debug("fetching verification queue", "game", gameID)
 	
-	fmt.Print("⏳ Loading user runs...")
-	body, err := api.makeRequest(fmt.Sprintf("/runs?user=%s&embed=game,category&orderby=date&direction=desc&max=25", userID))
-	fmt.Print("\r                      \r")
+	var runs []Run
+	for offset := 0; ; offset += RunHistoryPageSize {
+		showProgress("⏳", "Loading verification queue (%d so far)...", len(runs))
+		body, err := api.makeRequest(fmt.Sprintf("/runs?game=%s&status=new&orderby=submitted&direction=asc&max=%d&offset=%d",
+			url.QueryEscape(gameID), RunHistoryPageSize, offset))
+		clearProgress()
+		if err != nil {
+			return nil, err
+		}
+		
+		var apiResp APIResponse
+		if err := json.Unmarshal(body, &apiResp); err != nil {
+			return nil, &APIError{
+				Message: fmt.Sprintf("failed to parse JSON: %v", err),
+				Context: "JSON parsing",
+			}
+		}
+		
+		var page []Run
+		if err := json.Unmarshal(apiResp.Data, &page); err != nil {
+			return nil, &APIError{
+				Message: fmt.Sprintf("failed to parse runs data: %v", err),
+				Context: "runs data parsing",
+			}
+		}
+		runs = append(runs, page...)
+		
+		hasNext := false
+		for _, link := range apiResp.Pagination.Links {
+			hasNext = hasNext || link.Rel == "next"
+		}
+		if len(page) < RunHistoryPageSize || !hasNext {
+			break
+		}
+	}
+	
+	logger.Debug("found unverified runs", "count", len(runs))
+	return runs, nil
+}
+
+// GetRecentRuns returns the newest verified runs matching filter (game,
+// category, or user IDs), most recently submitted first.
+func (api *SpeedrunAPI) GetRecentRuns(filter url.Values, max int) ([]Run, error) {
+	logger.Debug("fetching recent runs", "filter", filter.Encode(), "max", max)
+	
+	params := url.Values{}
+	for key, values := range filter {
+		params[key] = values
+	}
+	params.Set("status", "verified")
+	params.Set("orderby", "submitted")
+	params.Set("direction", "desc")
+	params.Set("max", fmt.Sprint(max))
+	
+	showProgress("⏳", "Loading recent runs...")
+	body, err := api.makeRequest("/runs?" + params.Encode())
+	clearProgress()
 	
 	if err != nil {
 		return nil, err
 	}
-
-	var apiResp struct {
-		Data []struct {
-			ID       string          `json:"id"`
-			Weblink  string          `json:"weblink"`
-			Game     json.RawMessage `json:"game"`
-			Category json.RawMessage `json:"category"`
-			Date     string          `json:"date"`
-			Submitted time.Time      `json:"submitted"`
-			Times    struct {
-				Primary        string `json:"primary"`
-				Realtime       string `json:"realtime"`
-				RealtimeNoLoads string `json:"realtime_noloads"`
-				Ingame         string `json:"ingame"`
-			} `json:"times"`
-			Players []struct {
-				Rel  string `json:"rel"`
-				ID   string `json:"id"`
-				Name string `json:"name"`
-				URI  string `json:"uri"`
-			} `json:"players"`
-			System struct {
-				Platform string `json:"platform"`
-				Emulated bool   `json:"emulated"`
-				Region   string `json:"region"`
-			} `json:"system"`
-			Status struct {
-				Status string `json:"status"`
-				Reason string `json:"reason"`
-			} `json:"status"`
-			Videos struct {
-				Text  string `json:"text"`
-				Links []struct {
-					URI string `json:"uri"`
-				} `json:"links"`
-			} `json:"videos"`
-			Comment string `json:"comment"`
-			Place   int    `json:"place"`
-		} `json:"data"`
-		Embedded struct {
-			Games []struct {
-				Data Game `json:"data"`
-			} `json:"games"`
-			Categories []struct {
-				Data Category `json:"data"`
-			} `json:"categories"`
-		} `json:"embedded"`
-	}
 	
+	var apiResp APIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, &APIError{
 			Message: fmt.Sprintf("failed to parse JSON: %v", err),
 			Context: "JSON parsing",
 		}
 	}
-
-	gameMap := make(map[string]Game)
-	for _, gameWrapper := range apiResp.Embedded.Games {
-		gameMap[gameWrapper.Data.ID] = gameWrapper.Data
+	
+	var runs []Run
+	if err := json.Unmarshal(apiResp.Data, &runs); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse runs data: %v", err),
+			Context: "runs data parsing",
+		}
 	}
+	
+	logger.Debug("found recent runs", "count", len(runs))
+	return runs, nil
+}
 
-	categoryMap := make(map[string]Category)
-	for _, categoryWrapper := range apiResp.Embedded.Categories {
-		categoryMap[categoryWrapper.Data.ID] = categoryWrapper.Data
+// ProjectQueuePlacements works out where each queued run would place if it
+// were verified now. Leaderboards and variables are fetched once per board.
+func (api *SpeedrunAPI) ProjectQueuePlacements(runs []Run) map[string]QueuePlacement {
+	placements := make(map[string]QueuePlacement)
+	
+	showProgress("⏳", "Projecting placements...")
+	defer clearProgress()
+	
+	boards := api.runLeaderboards(runs)
+	for _, run := range runs {
+		if lb := boards[run.ID]; lb != nil {
+			placements[run.ID] = projectPlacement(run, lb)
+		}
+	}
+	
+	return placements
+}
+
+// GetRunPlaces returns the current place of each run on its leaderboard.
+// Runs that have been beaten by the same runner's later PB are left out.
+func (api *SpeedrunAPI) GetRunPlaces(runs []Run) map[string]int {
+	places := make(map[string]int)
+	
+	showProgress("⏳", "Loading placements...")
+	defer clearProgress()
+	
+	for runID, lb := range api.runLeaderboards(runs) {
+		for _, entry := range lb.Runs {
+			if entry.Run.ID == runID {
+				places[runID] = entry.Place
+				break
+			}
+		}
+	}
+	
+	return places
+}
+
+// runLeaderboards maps each run's ID to the leaderboard it competes on.
+// Leaderboards and variables are fetched once per board; runs whose board
+// fails to load are left out.
+func (api *SpeedrunAPI) runLeaderboards(runs []Run) map[string]*Leaderboard {
+	runBoards := make(map[string]*Leaderboard)
+	subcategoryVars := make(map[string]map[string]bool)
+	boards := make(map[string]*Leaderboard)
+	
+	for _, run := range runs {
+		vars, exists := subcategoryVars[run.Category]
+		if !exists {
+			vars = make(map[string]bool)
+			variables, err := api.GetVariables(run.Category)
+			if err != nil {
+				logger.Warn("failed to fetch variables", "category", run.Category, "error", err)
+			}
+			for _, variable := range variables {
+				if variable.IsSubcategory {
+					vars[variable.ID] = true
+				}
+			}
+			subcategoryVars[run.Category] = vars
+		}
+		
+		key := leaderboardKey(run, vars)
+		lb, exists := boards[key]
+		if !exists {
+			var err error
+			lb, err = api.GetRunLeaderboard(run, vars)
+			if err != nil {
+				logger.Warn("failed to fetch leaderboard for run", "run", run.ID, "error", err)
+			}
+			boards[key] = lb
+		}
+		
+		if lb != nil {
+			runBoards[run.ID] = lb
+		}
+	}
+	
+	return runBoards
+}
+
+func leaderboardKey(run Run, subcategoryVars map[string]bool) string {
+	var parts []string
+	for varID, valueID := range run.Values {
+		if subcategoryVars[varID] {
+			parts = append(parts, varID+"="+valueID)
+		}
+	}
+	sort.Strings(parts)
+	return run.Category + "/" + run.Level + "?" + strings.Join(parts, "&")
+}
+
+func projectPlacement(run Run, lb *Leaderboard) QueuePlacement {
+	runTime := ptSeconds(run.Times.Primary)
+	placement := QueuePlacement{Place: 1, IsPB: true, Checked: true}
+	
+	for _, entry := range lb.Runs {
+		entryTime := ptSeconds(entry.Run.Times.Primary)
+		if samePlayer(run, entry.Run) {
+			if entryTime <= runTime {
+				placement.IsPB = false
+			}
+			continue
+		}
+		if entryTime < runTime {
+			placement.Place++
+		}
+	}
+	
+	return placement
+}
+
+func samePlayer(a, b Run) bool {
+	if len(a.Players) == 0 || len(b.Players) == 0 {
+		return false
+	}
+	
+	pa, pb := a.Players[0], b.Players[0]
+	if pa.ID != "" {
+		return pa.ID == pb.ID
+	}
+	return pa.Name != "" && strings.EqualFold(pa.Name, pb.Name)
+}
+
+func (api *SpeedrunAPI) GetUserData(userID string) *User {
+	api.cacheMux.RLock()
+	if user, exists := api.userCache[userID]; exists {
+		api.cacheMux.RUnlock()
+		return user
+	}
+	api.cacheMux.RUnlock()
+
+	body, err := api.makeRequest(fmt.Sprintf("/users/%s", userID))
+	if err != nil {
+		logger.Warn("failed to fetch user", "user", userID, "error", err)
+		return nil
+	}
+
+	var response struct {
+		Data User `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		logger.Warn("failed to decode user", "user", userID, "error", err)
+		return nil
+	}
+
+	api.cacheMux.Lock()
+	api.userCache[userID] = &response.Data
+	api.cacheMux.Unlock()
+
+	return &response.Data
+}
+
+func (api *SpeedrunAPI) SearchUsers(query string) ([]User, error) {
+	logger.Debug("searching users", "query", query)
+	
+	encodedQuery := url.QueryEscape(query)
+	showProgress("🔍", "Searching for users...")
+	body, err := api.makeRequest(fmt.Sprintf("/users?lookup=%s&max=20", encodedQuery))
+	clearProgress()
+	
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp APIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	var users []User
+	if err := json.Unmarshal(apiResp.Data, &users); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse users data: %v", err),
+			Context: "users data parsing",
+		}
+	}
+
+	logger.Debug("found users", "count", len(users))
+	return users, nil
+}
+
+func (api *SpeedrunAPI) GetUserRuns(userID string) ([]UserRun, error) {
+	logger.Debug("fetching user runs", "user", userID)
+	
+	showProgress("⏳", "Loading user runs...")
+	body, err := api.makeRequest(fmt.Sprintf("/runs?user=%s&embed=game,category&orderby=date&direction=desc&max=25", userID))
+	clearProgress()
+	
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []struct {
+			ID       string          `json:"id"`
+			Weblink  string          `json:"weblink"`
+			Game     json.RawMessage `json:"game"`
+			Category json.RawMessage `json:"category"`
+			Date     string          `json:"date"`
+			Submitted time.Time      `json:"submitted"`
+			Times    struct {
+				Primary        string `json:"primary"`
+				Realtime       string `json:"realtime"`
+				RealtimeNoLoads string `json:"realtime_noloads"`
+				Ingame         string `json:"ingame"`
+			} `json:"times"`
+			Players []struct {
+				Rel  string `json:"rel"`
+				ID   string `json:"id"`
+				Name string `json:"name"`
+				URI  string `json:"uri"`
+			} `json:"players"`
+			System struct {
+				Platform string `json:"platform"`
+				Emulated bool   `json:"emulated"`
+				Region   string `json:"region"`
+			} `json:"system"`
+			Status struct {
+				Status string `json:"status"`
+				Reason string `json:"reason"`
+			} `json:"status"`
+			Videos struct {
+				Text  string `json:"text"`
+				Links []struct {
+					URI string `json:"uri"`
+				} `json:"links"`
+			} `json:"videos"`
+			Comment string `json:"comment"`
+			Place   int    `json:"place"`
+		} `json:"data"`
+		Embedded struct {
+			Games []struct {
+				Data Game `json:"data"`
+			} `json:"games"`
+			Categories []struct {
+				Data Category `json:"data"`
+			} `json:"categories"`
+		} `json:"embedded"`
+	}
+	
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	gameMap := make(map[string]Game)
+	for _, gameWrapper := range apiResp.Embedded.Games {
+		gameMap[gameWrapper.Data.ID] = gameWrapper.Data
+	}
+
+	categoryMap := make(map[string]Category)
+	for _, categoryWrapper := range apiResp.Embedded.Categories {
+		categoryMap[categoryWrapper.Data.ID] = categoryWrapper.Data
 	}
 
 	var userRuns []UserRun
@@ -575,10 +1164,637 @@ func (api *SpeedrunAPI) GetUserRuns(userID string) ([]UserRun, error) {
 		userRuns = append(userRuns, userRun)
 	}
 
-	debugLog("Found %d verified runs for user", len(userRuns))
+	logger.Debug("found verified user runs", "count", len(userRuns))
 	return userRuns, nil
 }
 
+// GetUserRunHistory pages through a runner's verified runs, newest first,
+// up to MaxProfileRuns. The second result reports whether the history was
+// cut off at the cap.
+func (api *SpeedrunAPI) GetUserRunHistory(userID string) ([]Run, bool, error) {
+	logger.Debug("fetching user run history", "user", userID)
+	
+	showProgress("⏳", "Loading run history...")
+	defer clearProgress()
+	
+	var runs []Run
+	for offset := 0; offset < MaxProfileRuns; offset += RunHistoryPageSize {
+		body, err := api.makeRequest(fmt.Sprintf("/runs?user=%s&status=verified&orderby=date&direction=desc&max=%d&offset=%d",
+			url.QueryEscape(userID), RunHistoryPageSize, offset))
+		if err != nil {
+			return nil, false, err
+		}
+		
+		var apiResp struct {
+			Data []struct {
+				Run
+				Game     json.RawMessage `json:"game"`
+				Category json.RawMessage `json:"category"`
+			} `json:"data"`
+			Pagination struct {
+				Links []struct {
+					Rel string `json:"rel"`
+				} `json:"links"`
+			} `json:"pagination"`
+		}
+		if err := json.Unmarshal(body, &apiResp); err != nil {
+			return nil, false, &APIError{
+				Message: fmt.Sprintf("failed to parse JSON: %v", err),
+				Context: "JSON parsing",
+			}
+		}
+		
+		for _, entry := range apiResp.Data {
+			run := entry.Run
+			run.Game, run.Category = embeddedID(entry.Game), embeddedID(entry.Category)
+			runs = append(runs, run)
+		}
+		
+		hasNext := false
+		for _, link := range apiResp.Pagination.Links {
+			hasNext = hasNext || link.Rel == "next"
+		}
+		if len(apiResp.Data) < RunHistoryPageSize || !hasNext {
+			return runs, false, nil
+		}
+	}
+	
+	logger.Debug("run history capped", "user", userID, "runs", len(runs))
+	return runs, true, nil
+}
+
+// CountGameRuns pages through a game's verified runs, full-game and
+// individual level, up to MaxGameStatsRuns. The second result reports
+// whether the count was cut off at the cap.
+func (api *SpeedrunAPI) CountGameRuns(gameID string) (int, bool, error) {
+	logger.Debug("counting game runs", "game", gameID)
+	
+	count := 0
+	for offset := 0; offset < MaxGameStatsRuns; offset += RunHistoryPageSize {
+		showProgress("⏳", "Counting runs (%d so far)...", count)
+		body, err := api.makeRequest(fmt.Sprintf("/runs?game=%s&status=verified&orderby=date&direction=desc&max=%d&offset=%d",
+			url.QueryEscape(gameID), RunHistoryPageSize, offset))
+		clearProgress()
+		if err != nil {
+			return 0, false, err
+		}
+		
+		var apiResp struct {
+			Data       []json.RawMessage `json:"data"`
+			Pagination struct {
+				Links []struct {
+					Rel string `json:"rel"`
+				} `json:"links"`
+			} `json:"pagination"`
+		}
+		if err := json.Unmarshal(body, &apiResp); err != nil {
+			return 0, false, &APIError{
+				Message: fmt.Sprintf("failed to parse JSON: %v", err),
+				Context: "JSON parsing",
+			}
+		}
+		count += len(apiResp.Data)
+		
+		hasNext := false
+		for _, link := range apiResp.Pagination.Links {
+			hasNext = hasNext || link.Rel == "next"
+		}
+		if len(apiResp.Data) < RunHistoryPageSize || !hasNext {
+			return count, false, nil
+		}
+	}
+	
+	logger.Debug("run count capped", "game", gameID, "runs", count)
+	return count, true, nil
+}
+
+// embeddedID reads a resource ID from a field that holds either the ID or
+// the embedded {"data": {...}} resource.
+func embeddedID(raw json.RawMessage) string {
+	var id string
+	if json.Unmarshal(raw, &id) == nil {
+		return id
+	}
+	var resource struct {
+		ID string `json:"id"`
+	}
+	unmarshalEmbeddedData(raw, &resource)
+	return resource.ID
+}
+
+// GetProfile returns the user the configured API key belongs to.
+func (api *SpeedrunAPI) GetProfile() (*User, error) {
+	if !api.HasAPIKey() {
+		return nil, &APIError{
+			Message: "no API key configured",
+			Context: "authentication",
+		}
+	}
+	
+	body, err := api.makeRequest("/profile")
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data User `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse profile data: %v", err),
+			Context: "profile data parsing",
+		}
+	}
+
+	return &response.Data, nil
+}
+
+// SetRunStatus verifies or rejects a run. Rejections require a reason.
+func (api *SpeedrunAPI) SetRunStatus(runID, status, reason string) error {
+	logger.Debug("setting run status", "run", runID, "status", status)
+	
+	if !api.HasAPIKey() {
+		return &APIError{
+			Message: "no API key configured",
+			Context: "authentication",
+		}
+	}
+	
+	var request struct {
+		Status struct {
+			Status string `json:"status"`
+			Reason string `json:"reason,omitempty"`
+		} `json:"status"`
+	}
+	request.Status.Status = status
+	request.Status.Reason = reason
+	
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	
+	_, err = api.makeRequestWithBody("PUT", fmt.Sprintf("/runs/%s/status", runID), payload)
+	return err
+}
+
+func (api *SpeedrunAPI) GetGameRegions(gameID string) ([]Region, error) {
+	logger.Debug("fetching regions", "game", gameID)
+	
+	body, err := api.makeRequest(fmt.Sprintf("/games/%s?embed=regions", gameID))
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data struct {
+			Regions struct {
+				Data []Region `json:"data"`
+			} `json:"regions"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	logger.Debug("found regions", "count", len(apiResp.Data.Regions.Data))
+	return apiResp.Data.Regions.Data, nil
+}
+
+// SubmitRun posts a JSON-encoded {"run": ...} payload and returns the new run.
+func (api *SpeedrunAPI) SubmitRun(payload []byte) (*Run, error) {
+	if !api.HasAPIKey() {
+		return nil, &APIError{
+			Message: "no API key configured",
+			Context: "authentication",
+		}
+	}
+	
+	body, err := api.makeRequestWithBody("POST", "/runs", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Run `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse submitted run: %v", err),
+			Context: "run data parsing",
+		}
+	}
+
+	return &response.Data, nil
+}
+
+func (api *SpeedrunAPI) GetNotifications() ([]Notification, error) {
+	logger.Debug("fetching notifications")
+	
+	if !api.HasAPIKey() {
+		return nil, &APIError{
+			Message: "no API key configured",
+			Context: "authentication",
+		}
+	}
+	
+	body, err := api.makeRequest("/notifications?orderby=created&direction=desc&max=50")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp APIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	var notifications []Notification
+	if err := json.Unmarshal(apiResp.Data, &notifications); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse notifications data: %v", err),
+			Context: "notifications data parsing",
+		}
+	}
+
+	logger.Debug("found notifications", "count", len(notifications))
+	return notifications, nil
+}
+
+// UnreadNotificationCount returns the last known number of unread
+// notifications. When that is older than NotificationCheckInterval it
+// starts a re-check in the background, so the new count shows on a later
+// prompt; failed checks are logged and keep the old count.
+func (api *SpeedrunAPI) UnreadNotificationCount() int {
+	if !api.HasAPIKey() {
+		return 0
+	}
+	
+	api.unreadMux.Lock()
+	defer api.unreadMux.Unlock()
+	
+	if !api.unreadChecking && time.Since(api.unreadChecked) >= NotificationCheckInterval*time.Second {
+		api.unreadChecking = true
+		go api.refreshUnreadCount()
+	}
+	return api.unreadCount
+}
+
+func (api *SpeedrunAPI) refreshUnreadCount() {
+	notifications, err := api.GetNotifications()
+	
+	api.unreadMux.Lock()
+	defer api.unreadMux.Unlock()
+	
+	api.unreadChecking = false
+	api.unreadChecked = time.Now()
+	if err != nil {
+		logger.Warn("failed to check notifications", "error", err)
+		return
+	}
+	api.unreadCount = countUnread(notifications)
+}
+
+func (api *SpeedrunAPI) setUnreadCount(count int) {
+	api.unreadMux.Lock()
+	defer api.unreadMux.Unlock()
+	
+	api.unreadCount = count
+	api.unreadChecked = time.Now()
+}
+
+func (api *SpeedrunAPI) SearchSeries(query string) ([]Series, error) {
+	logger.Debug("searching series", "query", query)
+	
+	encodedQuery := url.QueryEscape(query)
+	showProgress("🔍", "Searching for series...")
+	body, err := api.makeRequest(fmt.Sprintf("/series?name=%s&max=20", encodedQuery))
+	clearProgress()
+	
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp APIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	var series []Series
+	if err := json.Unmarshal(apiResp.Data, &series); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse series data: %v", err),
+			Context: "series data parsing",
+		}
+	}
+
+	logger.Debug("found series", "count", len(series))
+	return series, nil
+}
+
+func (api *SpeedrunAPI) GetSeriesGames(seriesID string) ([]Game, error) {
+	logger.Debug("fetching series games", "series", seriesID)
+	
+	body, err := api.makeRequest(fmt.Sprintf("/series/%s/games?max=200&embed=categories&orderby=released", seriesID))
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp APIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+
+	var games []Game
+	if err := json.Unmarshal(apiResp.Data, &games); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse games data: %v", err),
+			Context: "games data parsing",
+		}
+	}
+
+	logger.Debug("found series games", "count", len(games))
+	return games, nil
+}
+
+// GetSeriesOverview picks each game's most popular full-game category (by
+// runs on its leaderboard) and returns that category's current record.
+func (api *SpeedrunAPI) GetSeriesOverview(games []Game) []SeriesEntry {
+	var requests []boardRequest
+	var owners []int
+	for i, game := range games {
+		for _, category := range game.Categories.Data {
+			if category.Type == "per-game" && !category.Miscellaneous {
+				requests = append(requests, boardRequest{Game: game, Category: category})
+				owners = append(owners, i)
+			}
+		}
+	}
+	
+	showProgress("⏳", "Loading %d leaderboards...", len(requests))
+	boards := api.fetchLeaderboards(requests)
+	clearProgress()
+	
+	entries := make([]SeriesEntry, len(games))
+	for i, game := range games {
+		entries[i].Game = game
+	}
+	
+	// Boards are visited in speedrun.com's category order, so ties go to
+	// the category listed first
+	for i, board := range boards {
+		entry := &entries[owners[i]]
+		if board == nil || len(board.Runs) <= entry.Runners {
+			continue
+		}
+		
+		entry.Category = requests[i].Category
+		entry.Runners = len(board.Runs)
+		entry.Record = &board.Runs[0].Run
+		entry.Platform = getPlatformName(board.Runs[0].Run, board.PlatformMap)
+	}
+	
+	return entries
+}
+
+type boardRequest struct {
+	Game     Game
+	Category Category
+	// Values narrows the board to subcategory values (variable ID to value
+	// ID); Label names them for display
+	Values map[string]string
+	Label  string
+}
+
+// fetchLeaderboards loads the full-game leaderboard for each request with
+// at most LeaderboardFetchWorkers in flight. Boards that fail to load are
+// left nil.
+func (api *SpeedrunAPI) fetchLeaderboards(requests []boardRequest) []*Leaderboard {
+	boards := make([]*Leaderboard, len(requests))
+	semaphore := make(chan struct{}, LeaderboardFetchWorkers)
+	var wg sync.WaitGroup
+	
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request boardRequest) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			
+			params := url.Values{"embed": {"platforms"}}
+			for varID, valueID := range request.Values {
+				params.Set("var-"+varID, valueID)
+			}
+			body, err := api.makeRequest(fmt.Sprintf("/leaderboards/%s/category/%s?%s", request.Game.ID, request.Category.ID, params.Encode()))
+			if err == nil {
+				boards[i], err = parseLeaderboard(body)
+			}
+			if err != nil {
+				logger.Warn("failed to fetch leaderboard", "game", request.Game.ID, "category", request.Category.ID, "error", err)
+			}
+		}(i, request)
+	}
+	
+	wg.Wait()
+	return boards
+}
+
+func (api *SpeedrunAPI) GetGameOverview(gameID string) (*GameOverview, error) {
+	logger.Debug("fetching game overview", "game", gameID)
+	
+	showProgress("⏳", "Loading game overview...")
+	defer clearProgress()
+	
+	body, err := api.makeRequest(fmt.Sprintf("/games/%s?embed=platforms,regions,genres,engines,developers,publishers,moderators,categories", gameID))
+	if err != nil {
+		return nil, err
+	}
+	
+	type named struct {
+		Name string `json:"name"`
+	}
+	var apiResp struct {
+		Data struct {
+			ID    string `json:"id"`
+			Names struct {
+				International string `json:"international"`
+				Japanese      string `json:"japanese"`
+			} `json:"names"`
+			Abbreviation string  `json:"abbreviation"`
+			Released     int     `json:"released"`
+			ReleaseDate  string  `json:"release-date"`
+			Weblink      string  `json:"weblink"`
+			Ruleset      Ruleset `json:"ruleset"`
+			Platforms    struct {
+				Data []named `json:"data"`
+			} `json:"platforms"`
+			Regions struct {
+				Data []named `json:"data"`
+			} `json:"regions"`
+			Genres struct {
+				Data []named `json:"data"`
+			} `json:"genres"`
+			Engines struct {
+				Data []named `json:"data"`
+			} `json:"engines"`
+			Developers struct {
+				Data []named `json:"data"`
+			} `json:"developers"`
+			Publishers struct {
+				Data []named `json:"data"`
+			} `json:"publishers"`
+			Moderators struct {
+				Data []User `json:"data"`
+			} `json:"moderators"`
+			Categories struct {
+				Data []Category `json:"data"`
+			} `json:"categories"`
+		} `json:"data"`
+	}
+	
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse JSON: %v", err),
+			Context: "JSON parsing",
+		}
+	}
+	
+	names := func(items []named) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.Name
+		}
+		return result
+	}
+	
+	data := apiResp.Data
+	overview := &GameOverview{
+		Platforms:  names(data.Platforms.Data),
+		Regions:    names(data.Regions.Data),
+		Genres:     names(data.Genres.Data),
+		Engines:    names(data.Engines.Data),
+		Developers: names(data.Developers.Data),
+		Publishers: names(data.Publishers.Data),
+	}
+	overview.Game.ID = data.ID
+	overview.Game.Names = data.Names
+	overview.Game.Abbreviation = data.Abbreviation
+	overview.Game.Released = data.Released
+	overview.Game.ReleaseDate = data.ReleaseDate
+	overview.Game.Weblink = data.Weblink
+	overview.Game.Ruleset = data.Ruleset
+	overview.Game.Categories = data.Categories
+	
+	for _, moderator := range data.Moderators.Data {
+		overview.Moderators = append(overview.Moderators, moderator.Names.International)
+	}
+	
+	var requests []boardRequest
+	for _, category := range data.Categories.Data {
+		overview.Categories = append(overview.Categories, CategorySummary{Category: category})
+		if category.Type == "per-game" {
+			requests = append(requests, boardRequest{Game: overview.Game, Category: category})
+		}
+	}
+	
+	boards := api.fetchLeaderboards(requests)
+	boardIdx := 0
+	for i := range overview.Categories {
+		if overview.Categories[i].Category.Type != "per-game" {
+			continue
+		}
+		board := boards[boardIdx]
+		boardIdx++
+		if board == nil {
+			continue
+		}
+		overview.Categories[i].Runners = len(board.Runs)
+		if len(board.Runs) > 0 {
+			overview.Categories[i].Record = &board.Runs[0].Run
+		}
+	}
+	
+	logger.Debug("loaded game overview", "categories", len(overview.Categories))
+	return overview, nil
+}
+
+// GetGame looks a game up by ID or abbreviation.
+func (api *SpeedrunAPI) GetGame(idOrAbbreviation string) (*Game, error) {
+	logger.Debug("fetching game", "game", idOrAbbreviation)
+	
+	body, err := api.makeRequest(fmt.Sprintf("/games/%s", url.PathEscape(idOrAbbreviation)))
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Game `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse game data: %v", err),
+			Context: "game data parsing",
+		}
+	}
+
+	return &response.Data, nil
+}
+
+func (api *SpeedrunAPI) GetRun(runID string) (*Run, error) {
+	logger.Debug("fetching run", "run", runID)
+	
+	body, err := api.makeRequest(fmt.Sprintf("/runs/%s", url.PathEscape(runID)))
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Run `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse run data: %v", err),
+			Context: "run data parsing",
+		}
+	}
+
+	return &response.Data, nil
+}
+
+// GetUser looks a user up by ID or username.
+func (api *SpeedrunAPI) GetUser(idOrName string) (*User, error) {
+	logger.Debug("fetching user", "user", idOrName)
+	
+	body, err := api.makeRequest(fmt.Sprintf("/users/%s", url.PathEscape(idOrName)))
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data User `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, &APIError{
+			Message: fmt.Sprintf("failed to parse user data: %v", err),
+			Context: "user data parsing",
+		}
+	}
+
+	return &response.Data, nil
+}
+
 func fetchUserData(userID string) *struct {
 	Names struct {
 		International string `json:"international"`
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const BookmarksFileName = "bookmarks.json"
+
+func bookmarksPath() string {
+	return filepath.Join(configDir(), BookmarksFileName)
+}
+
+// loadBookmarks returns the bookmarked games, or nil when there are none.
+func loadBookmarks() []SavedGame {
+	data, err := os.ReadFile(bookmarksPath())
+	if err != nil {
+		return nil
+	}
+	var bookmarks []SavedGame
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil
+	}
+	return bookmarks
+}
+
+func saveBookmarks(bookmarks []SavedGame) error {
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDir(), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(bookmarksPath(), data, 0600)
+}
+
+func isBookmarked(gameID string) bool {
+	for _, bookmark := range loadBookmarks() {
+		if bookmark.ID == gameID {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleBookmark adds or removes game from the bookmarks and reports
+// whether it is now bookmarked.
+func toggleBookmark(game *Game) (bool, error) {
+	var kept []SavedGame
+	removed := false
+	for _, bookmark := range loadBookmarks() {
+		if bookmark.ID == game.ID {
+			removed = true
+			continue
+		}
+		kept = append(kept, bookmark)
+	}
+	
+	if !removed {
+		kept = append(kept, newSavedGame(game))
+	}
+	return !removed, saveBookmarks(kept)
+}
+
+// bookmarkPrompt describes the '*' key on the game overview prompt.
+func bookmarkPrompt(gameID string) string {
+	if isBookmarked(gameID) {
+		return "'*' to remove bookmark"
+	}
+	return "'*' to bookmark"
+}
+
+// selectBookmark lists the bookmarks and returns the chosen one, or nil.
+func selectBookmark() *SavedGame {
+	bookmarks := loadBookmarks()
+	if len(bookmarks) == 0 {
+		fmt.Println("No bookmarks yet. Press '*' on a game overview to bookmark it.")
+		return nil
+	}
+	
+	fmt.Printf("\n%sBookmarks:\n", icon("⭐"))
+	for i, bookmark := range bookmarks {
+		fmt.Printf("%d. %s (%s)\n", i+1, bookmark.Name, bookmark.Abbreviation)
+	}
+	
+	choice := getUserChoice("\nEnter number to open, 'b' to go back, 'q' to quit: ", len(bookmarks), true)
+	
+	if choice.IsQuit {
+		fmt.Println(goodbye())
+		os.Exit(0)
+	}
+	
+	if choice.Index >= 0 {
+		return &bookmarks[choice.Index]
+	}
+	
+	return nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// responseCache keeps GET response bodies on disk under
+// $XDG_CACHE_HOME/speedrun-cli. Files are laid out by endpoint path, e.g.
+// leaderboards/<game>/category/<category>/<query hash>.json, so a whole
+// resource can be invalidated at once. Other API bases (a fake server, a
+// LAN mirror) get their own directory so their responses never mix with
+// speedrun.com's.
+type responseCache struct {
+	dir  string
+	ttls map[string]time.Duration
+}
+
+type cacheEntry struct {
+	Endpoint  string          `json:"endpoint"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// cacheClasses maps the first path segment of an endpoint to the setting
+// holding its TTL. Endpoints not listed here (runs, notifications,
+// profile) are never cached.
+var cacheClasses = map[string]string{
+	"games":        "cache_ttl_games",
+	"categories":   "cache_ttl_games",
+	"variables":    "cache_ttl_games",
+	"levels":       "cache_ttl_games",
+	"platforms":    "cache_ttl_games",
+	"regions":      "cache_ttl_games",
+	"series":       "cache_ttl_games",
+	"leaderboards": "cache_ttl_leaderboards",
+	"users":        "cache_ttl_users",
+}
+
+// cacheDir returns $XDG_CACHE_HOME/speedrun-cli, falling back to
+// ~/.cache/speedrun-cli.
+func cacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, ConfigDirName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".cache", ConfigDirName)
+	}
+	return filepath.Join(home, ".cache", ConfigDirName)
+}
+
+func newResponseCache(s *Settings) *responseCache {
+	cache := &responseCache{
+		dir:  apiCacheDir(s.String("api_base")),
+		ttls: make(map[string]time.Duration),
+	}
+	for segment, key := range cacheClasses {
+		cache.ttls[segment] = s.Duration(key)
+	}
+	return cache
+}
+
+// apiCacheDir returns the cache directory for an API base: cacheDir() for
+// speedrun.com, or hosts/<base hash> under it for any other base.
+func apiCacheDir(base string) string {
+	base = strings.TrimSuffix(base, "/")
+	if base == "" || base == DefaultAPIBase {
+		return cacheDir()
+	}
+	sum := sha256.Sum256([]byte(base))
+	return filepath.Join(cacheDir(), "hosts", hex.EncodeToString(sum[:8]))
+}
+
+func (c *responseCache) ttl(endpoint string) time.Duration {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(endpoint, "/"), "/")
+	segment, _, _ = strings.Cut(segment, "?")
+	return c.ttls[segment]
+}
+
+// path maps an endpoint to its cache file. Path segments become
+// directories; the query string is hashed into the file name.
+func (c *responseCache) path(endpoint string) string {
+	endpointPath, query, _ := strings.Cut(endpoint, "?")
+	
+	parts := []string{c.dir}
+	for _, segment := range strings.Split(strings.Trim(endpointPath, "/"), "/") {
+		parts = append(parts, cacheSegment(segment))
+	}
+	
+	sum := sha256.Sum256([]byte(query))
+	parts = append(parts, hex.EncodeToString(sum[:8])+".json")
+	return filepath.Join(parts...)
+}
+
+func cacheSegment(segment string) string {
+	segment = url.PathEscape(segment)
+	if segment == "" || segment == "." || segment == ".." {
+		return "_" + segment
+	}
+	return segment
+}
+
+// enabled reports whether responses for endpoint are cached at all.
+func (c *responseCache) enabled(endpoint string) bool {
+	return c != nil && c.ttl(endpoint) > 0
+}
+
+// get returns a cached body that is younger than its TTL.
+func (c *responseCache) get(endpoint string) ([]byte, bool) {
+	if !c.enabled(endpoint) {
+		return nil, false
+	}
+	
+	entry, ok := c.read(endpoint)
+	if !ok || time.Since(entry.FetchedAt) > c.ttl(endpoint) {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+func (c *responseCache) read(endpoint string) (cacheEntry, bool) {
+	var entry cacheEntry
+	data, err := os.ReadFile(c.path(endpoint))
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Endpoint != endpoint {
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *responseCache) put(endpoint string, body []byte) {
+	if !c.enabled(endpoint) || !json.Valid(body) {
+		return
+	}
+	
+	data, err := json.Marshal(cacheEntry{Endpoint: endpoint, FetchedAt: time.Now(), Body: body})
+	if err != nil {
+		return
+	}
+	
+	path := c.path(endpoint)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		logger.Warn("failed to create cache directory", "error", err)
+		return
+	}
+	
+	// Write to a file of our own, then rename, so concurrent readers never
+	// see a partial file and concurrent writers never share a temp file
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		logger.Warn("failed to write cache entry", "endpoint", endpoint, "error", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		logger.Warn("failed to write cache entry", "endpoint", endpoint, "error", err)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		logger.Warn("failed to write cache entry", "endpoint", endpoint, "error", err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		logger.Warn("failed to write cache entry", "endpoint", endpoint, "error", err)
+	}
+}
+
+// invalidate removes every cached response under an endpoint path.
+func (c *responseCache) invalidate(endpointPath string) {
+	if c == nil {
+		return
+	}
+	dir := filepath.Dir(c.path(strings.TrimSuffix(endpointPath, "/")))
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Warn("failed to invalidate cache", "endpoint", endpointPath, "error", err)
+	}
+}
+
+// entries returns every cached response under an endpoint path, whatever
+// its age. Shell completion uses it to avoid the network.
+func (c *responseCache) entries(endpointPath string) []cacheEntry {
+	if c == nil {
+		return nil
+	}
+	
+	var entries []cacheEntry
+	dir := filepath.Dir(c.path(strings.TrimSuffix(endpointPath, "/")))
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		var entry cacheEntry
+		if json.Unmarshal(data, &entry) == nil {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	return entries
+}
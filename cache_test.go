@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestResponseCacheConcurrentPuts writes one endpoint from many goroutines
+// while others read it, as the parallel fetches of a stats run do. Readers
+// only ever see a whole entry, and no temp files are left behind.
+func TestResponseCacheConcurrentPuts(t *testing.T) {
+	cache := &responseCache{dir: t.TempDir(), ttls: map[string]time.Duration{"games": time.Hour}}
+	endpoint := "/games/sm64?embed=categories"
+	
+	bodies := make(map[string]bool)
+	for i := 0; i < 16; i++ {
+		bodies[fmt.Sprintf(`{"data":{"id":"o1y9wo6q","writer":%d,"padding":"%0*d"}}`, i, 4096, 0)] = true
+	}
+	
+	var wg sync.WaitGroup
+	for body := range bodies {
+		wg.Add(1)
+		go func(body string) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				cache.put(endpoint, []byte(body))
+			}
+		}(body)
+	}
+	
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if body, ok := cache.get(endpoint); ok && !bodies[string(body)] {
+					t.Errorf("read a partial or mixed entry of %d bytes", len(body))
+					return
+				}
+			}
+		}()
+	}
+	
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+	
+	body, ok := cache.get(endpoint)
+	if !ok || !bodies[string(body)] {
+		t.Fatalf("get() = %d bytes, %v after the writes", len(body), ok)
+	}
+	
+	files, err := os.ReadDir(filepath.Dir(cache.path(endpoint)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != filepath.Base(cache.path(endpoint)) {
+		var names []string
+		for _, file := range files {
+			names = append(names, file.Name())
+		}
+		t.Errorf("cache directory holds %q, want only the entry", names)
+	}
+}
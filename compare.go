@@ -0,0 +1,242 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Comparisons put two leaderboards of a game side by side: two
+// categories, two subcategory values, or one board under two filters
+// (e.g. N64 against emulator). Runners are joined by player ID.
+
+// CompareBoard is one side of a comparison.
+type CompareBoard struct {
+	Title string
+	Board *Leaderboard
+}
+
+// CompareEntry is one runner in a comparison. A nil run means the runner
+// is not on that board.
+type CompareEntry struct {
+	Runner     string
+	Left       *Run
+	Right      *Run
+	LeftPlace  int
+	RightPlace int
+}
+
+func handleCompare(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	subcategory := fs.String("subcategory", "", "subcategory of the first board")
+	vsSubcategory := fs.String("vs-subcategory", "", "subcategory of the second board (default: -subcategory)")
+	filter := fs.String("filter", "", "filter for the first board, e.g. \"platform:N64 !emu\"")
+	vsFilter := fs.String("vs-filter", "", "filter for the second board, e.g. \"emu\"")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli compare [flags] <game> <category> [<second category>]")
+		fmt.Fprintln(fs.Output(), "\nExamples:")
+		fmt.Fprintln(fs.Output(), "  speedrun-cli compare -subcategory JP sm64 120_Star 70_Star")
+		fmt.Fprintln(fs.Output(), "  speedrun-cli compare -subcategory JP -vs-subcategory US sm64 120_Star")
+		fmt.Fprintln(fs.Output(), "  speedrun-cli compare -subcategory JP -filter '!emu' -vs-filter emu sm64 120_Star")
+		fmt.Fprintln(fs.Output(), "\nFlags:")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if fs.NArg() < 2 || fs.NArg() > 3 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	
+	gameName, leftCategory, rightCategory := fs.Arg(0), fs.Arg(1), fs.Arg(1)
+	if fs.NArg() == 3 {
+		rightCategory = fs.Arg(2)
+	}
+	rightSubcategory := *vsSubcategory
+	if rightSubcategory == "" {
+		rightSubcategory = *subcategory
+	}
+	if leftCategory == rightCategory && *subcategory == rightSubcategory && *filter == *vsFilter {
+		fmt.Println("Error: both boards are the same; pass a second category, -vs-subcategory, or -vs-filter")
+		os.Exit(1)
+	}
+	
+	left, err := loadCompareBoard(api, gameName, leftCategory, *subcategory, *filter)
+	if err != nil {
+		fmt.Printf("Error loading the first board: %v\n", err)
+		os.Exit(1)
+	}
+	right, err := loadCompareBoard(api, gameName, rightCategory, rightSubcategory, *vsFilter)
+	if err != nil {
+		fmt.Printf("Error loading the second board: %v\n", err)
+		os.Exit(1)
+	}
+	
+	displayComparison(left, right, compareLeaderboards(left.Board, right.Board))
+}
+
+// loadCompareBoard fetches one side with every runner's name and applies
+// its filter. The title names the category, subcategory, and filter.
+func loadCompareBoard(api *SpeedrunAPI, gameName, categoryName, subcategoryName, filter string) (*CompareBoard, error) {
+	game, category, sub, err := resolveBoard(api, gameName, categoryName, subcategoryName)
+	if err != nil {
+		return nil, err
+	}
+	
+	subcategoryID, title := "", category.Name
+	if sub != nil {
+		subcategoryID = sub.ID
+		title += " (" + sub.Label + ")"
+	}
+	
+	lb, err := loadBoardAsOf(api, game.ID, category.ID, subcategoryID, "")
+	if err != nil {
+		return nil, err
+	}
+	if filter == "" {
+		return &CompareBoard{Title: title, Board: lb}, nil
+	}
+	
+	view := newLeaderboardView(lb)
+	if err := view.applyFilter(api, filter); err != nil {
+		return nil, err
+	}
+	return &CompareBoard{Title: title + " [" + filter + "]", Board: rerankBoard(view.shown)}, nil
+}
+
+// rerankBoard places a filtered board's runs among themselves, in board
+// order, with tied times sharing a place. Filtered views keep the places
+// runs have on the full board, which would compare a runner's 5th among
+// emulator runs as 12th.
+func rerankBoard(lb *Leaderboard) *Leaderboard {
+	ranked := *lb
+	ranked.Runs = append(lb.Runs[:0:0], lb.Runs...)
+	for i := range ranked.Runs {
+		ranked.Runs[i].Place = i + 1
+		if i > 0 && ranked.Runs[i].Run.Times.Primary == ranked.Runs[i-1].Run.Times.Primary {
+			ranked.Runs[i].Place = ranked.Runs[i-1].Place
+		}
+	}
+	return &ranked
+}
+
+// compareLeaderboards joins two boards by runner: runners on both in the
+// first board's order, then those only on the first, then those only on
+// the second.
+func compareLeaderboards(left, right *Leaderboard) []CompareEntry {
+	rightIndex := make(map[string]int)
+	for i, entry := range right.Runs {
+		if _, seen := rightIndex[runnerKey(entry.Run)]; !seen {
+			rightIndex[runnerKey(entry.Run)] = i
+		}
+	}
+	
+	var both, leftOnly, rightOnly []CompareEntry
+	seen := make(map[string]bool)
+	for i := range left.Runs {
+		entry := &left.Runs[i]
+		key := runnerKey(entry.Run)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		
+		compared := CompareEntry{Runner: boardRunnerNames(entry.Run, left), Left: &entry.Run, LeftPlace: entry.Place}
+		if j, ok := rightIndex[key]; ok {
+			compared.Right, compared.RightPlace = &right.Runs[j].Run, right.Runs[j].Place
+			both = append(both, compared)
+		} else {
+			leftOnly = append(leftOnly, compared)
+		}
+	}
+	
+	for i := range right.Runs {
+		entry := &right.Runs[i]
+		key := runnerKey(entry.Run)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rightOnly = append(rightOnly, CompareEntry{Runner: boardRunnerNames(entry.Run, right), Right: &entry.Run, RightPlace: entry.Place})
+	}
+	
+	return append(append(both, leftOnly...), rightOnly...)
+}
+
+func displayComparison(left, right *CompareBoard, entries []CompareEntry) {
+	colors := theme.Colors
+	game := left.Board.Game.Data.Names.International
+	
+	fmt.Printf("\n%s%s\n", icon("⚖️"), game)
+	fmt.Printf("A: %s\nB: %s\n", left.Title, right.Title)
+	
+	var both, leftOnly, rightOnly int
+	for _, entry := range entries {
+		switch {
+		case entry.Left != nil && entry.Right != nil:
+			both++
+		case entry.Left != nil:
+			leftOnly++
+		default:
+			rightOnly++
+		}
+	}
+	fmt.Printf("%s%d runners on both, %d only on A, %d only on B\n\n", icon("📈"), both, leftOnly, rightOnly)
+	
+	if len(entries) == 0 {
+		fmt.Println("No runs on either board.")
+		return
+	}
+	
+	runners := make([]string, len(entries))
+	for i, entry := range entries {
+		runners[i] = entry.Runner
+	}
+	columns := []TableColumn{
+		textColumn("Runner", runners, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Rank A", Width: 6},
+		{Title: "Time A", Width: TimeColumnWidth},
+		{Title: "Rank B", Width: 6},
+		{Title: "Time B", Width: TimeColumnWidth},
+		{Title: "B - A", Width: 13, DropRank: 1},
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	
+	for i, entry := range entries {
+		leftRank, leftTime := compareSide(entry.Left, entry.LeftPlace, colors)
+		rightRank, rightTime := compareSide(entry.Right, entry.RightPlace, colors)
+		
+		gap := EmptyValuePlaceholder
+		if entry.Left != nil && entry.Right != nil {
+			gap = formatSignedGap(getBestSeconds(*entry.Right)-getBestSeconds(*entry.Left), colors)
+		}
+		
+		fmt.Println(formatTableRow(columns, []string{runners[i], leftRank, leftTime, rightRank, rightTime, gap}))
+	}
+}
+
+func compareSide(run *Run, place int, colors Colors) (string, string) {
+	if run == nil {
+		return EmptyValuePlaceholder, EmptyValuePlaceholder
+	}
+	return strings.TrimRight(formatRank(place, colors), " "), getBestTime(*run)
+}
+
+// formatSignedGap shows how much slower (red, +) or faster (green, -) the
+// second board's time is.
+func formatSignedGap(seconds float64, colors Colors) string {
+	switch {
+	case seconds > 0:
+		return colors.Red + "+" + formatGap(seconds) + colors.Reset
+	case seconds < 0:
+		return colors.Green + "-" + formatGap(math.Abs(seconds)) + colors.Reset
+	default:
+		return "="
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func compareBoards(t *testing.T, api *SpeedrunAPI, left, right [2]string) (*CompareBoard, *CompareBoard) {
+	t.Helper()
+	l, err := loadCompareBoard(api, "sm64", "120 Star", left[0], left[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := loadCompareBoard(api, "sm64", "120 Star", right[0], right[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, r
+}
+
+func compareStrings(entries []CompareEntry) []string {
+	var out []string
+	for _, entry := range entries {
+		out = append(out, fmt.Sprintf("%s %d %d", entry.Runner, entry.LeftPlace, entry.RightPlace))
+	}
+	return out
+}
+
+// TestCompareVersions compares sm64 120 Star on JP against US: the twelve
+// US runners all run JP too, so they come first in JP order, followed by
+// the JP-only runners.
+func TestCompareVersions(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	left, right := compareBoards(t, api, [2]string{"JP", ""}, [2]string{"us", ""})
+	if left.Title != "120 Star (JP)" || right.Title != "120 Star (US)" {
+		t.Errorf("titles = %q, %q", left.Title, right.Title)
+	}
+	
+	entries := compareLeaderboards(left.Board, right.Board)
+	if len(entries) != 40 {
+		t.Fatalf("compareLeaderboards() has %d entries, want 40", len(entries))
+	}
+	for _, entry := range entries {
+		if (entry.Left == nil) != (entry.LeftPlace == 0) || (entry.Right == nil) != (entry.RightPlace == 0) {
+			t.Errorf("%s: runs and places disagree: %+v", entry.Runner, entry)
+		}
+	}
+	
+	got := compareStrings(entries[:15])
+	want := []string{
+		"cheese 1 1", "Dwhatever 4 2", "ikori_o 7 3", "Marbler 10 4", "Bubzia 13 5", "Mekaniak 16 6",
+		"Kalvin 19 7", "Shizzal 22 8", "azure 25 9", "coolguy42 28 10", "Yaiba 31 11", "ChocoPie 34 12",
+		"Suigi 2 0", "Weegee 3 0", "Simply 5 0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compareLeaderboards() starts\n%q\nwant\n%q", got, want)
+	}
+	if last := entries[len(entries)-1]; last.Runner != "pixelbird" || last.LeftPlace != 40 || last.Right != nil {
+		t.Errorf("last entry = %+v, want pixelbird only on JP", last)
+	}
+	if dw := entries[1]; dw.Left.ID != "r00004" || dw.Right.ID != "r00047" {
+		t.Errorf("Dwhatever compares %s with %s, want r00004 with r00047", dw.Left.ID, dw.Right.ID)
+	}
+}
+
+// TestCompareFilteredBoards splits the JP board into console and emulator
+// runs. Each side is ranked among its own runs, so the emulator board's
+// places start at 1 and a planted guest run that ties Glitchslayer shares
+// their place.
+func TestCompareFilteredBoards(t *testing.T) {
+	api, server := startFakeAPI(t, fakeFaults{})
+	plantRun(t, server, "r00021", fakeObject{"id": "rguest01", "players": []interface{}{fakeObject{"rel": "guest", "name": "Toad"}}, "date": "2026-09-30"})
+	
+	left, right := compareBoards(t, api, [2]string{"JP", "!emu"}, [2]string{"JP", "emu"})
+	if left.Title != "120 Star (JP) [!emu]" || right.Title != "120 Star (JP) [emu]" {
+		t.Errorf("titles = %q, %q", left.Title, right.Title)
+	}
+	
+	entries := compareLeaderboards(left.Board, right.Board)
+	if len(entries) != 41 {
+		t.Fatalf("compareLeaderboards() has %d entries, want 41", len(entries))
+	}
+	// Finnii602 was 8th on the full board
+	if got, want := compareStrings(entries[6:8]), []string{"ikori_o 7 0", "Puncayshun 8 0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("console runs = %q, want %q", got, want)
+	}
+	if got, want := compareStrings(entries[37:]), []string{"Finnii602 0 1", "Glitchslayer 0 2", "Toad 0 2", "ChocoPie 0 4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("emulator runs = %q, want %q", got, want)
+	}
+}
+
+func TestRerankBoardKeepsTheView(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	lb, err := loadBoardAsOf(api, "o1y9wo6q", "wkpoo02r", "9qj7z0oq", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	view := newLeaderboardView(lb)
+	if err := view.applyFilter(api, "emu"); err != nil {
+		t.Fatal(err)
+	}
+	
+	ranked := rerankBoard(view.shown)
+	if got := shownPlaces(view); !reflect.DeepEqual(got, []int{8, 21, 34}) {
+		t.Errorf("view places = %v after rerankBoard, want the full board's", got)
+	}
+	var places []int
+	for _, entry := range ranked.Runs {
+		places = append(places, entry.Place)
+	}
+	if !reflect.DeepEqual(places, []int{1, 2, 3}) || ranked.Filter != "emu" {
+		t.Errorf("rerankBoard() places = %v, filter %q", places, ranked.Filter)
+	}
+}
+
+func TestLoadCompareBoardErrors(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	for _, tt := range []struct{ subcategory, filter, want string }{
+		{subcategory: "PAL", want: "PAL"},
+		{subcategory: "JP", filter: "platform:", want: "platform"},
+	} {
+		if _, err := loadCompareBoard(api, "sm64", "120 Star", tt.subcategory, tt.filter); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadCompareBoard(%q, %q) error = %v, want it to mention %q", tt.subcategory, tt.filter, err, tt.want)
+		}
+	}
+}
+
+func TestDisplayComparison(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	left, right := compareBoards(t, api, [2]string{"JP", ""}, [2]string{"US", ""})
+	plainOutput(t, 100, 0)
+	
+	out := captureStdout(t, func() { displayComparison(left, right, compareLeaderboards(left.Board, right.Board)) })
+	
+	for _, want := range []string{
+		"Super Mario 64\nA: 120 Star (JP)\nB: 120 Star (US)\n",
+		"12 runners on both, 28 only on A, 0 only on B",
+		"cheese",
+		"+5:20",
+		"-0:34",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("comparison is missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The completion scripts call the hidden "__complete" subcommand with the
+// words typed so far; it prints one candidate per line.
+
+const bashCompletion = `# bash completion for speedrun-cli
+_speedrun_cli() {
+    local IFS=$'\n'
+    COMPREPLY=($(speedrun-cli __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _speedrun_cli speedrun-cli
+`
+
+const zshCompletion = `#compdef speedrun-cli
+# zsh completion for speedrun-cli
+_speedrun_cli() {
+    local -a candidates
+    candidates=(${(f)"$(speedrun-cli __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    compadd -a candidates
+}
+if [ "$funcstack[1]" = "_speedrun_cli" ]; then
+    _speedrun_cli "$@"
+else
+    compdef _speedrun_cli speedrun-cli
+fi
+`
+
+const fishCompletion = `# fish completion for speedrun-cli
+function __speedrun_cli_complete
+    set -l words (commandline -opc) (commandline -ct)
+    speedrun-cli __complete $words[2..-1] 2>/dev/null
+end
+complete -c speedrun-cli -f -a '(__speedrun_cli_complete)'
+`
+
+var completionScripts = map[string]string{
+	"bash": bashCompletion,
+	"zsh":  zshCompletion,
+	"fish": fishCompletion,
+}
+
+var completionSubcommands = []string{
+	"leaderboard", "diff", "compare", "profile", "game-stats", "open", "queue", "submit", "notifications", "inbox",
+	"config", "login", "logout", "completion", "feed", "overlay", "serve", "sync", "fake-server", "--help", "--version",
+}
+
+// CompletionTimeout bounds each API request made while completing.
+const CompletionTimeout = 2 * time.Second
+
+var configSubcommands = []string{"list", "get", "set", "unset", "use", "profiles", "path"}
+
+func handleCompletion(args []string) {
+	if len(args) != 1 || completionScripts[args[0]] == "" {
+		fmt.Println("Usage: speedrun-cli completion bash|zsh|fish")
+		fmt.Println()
+		fmt.Println("Load completions in the current shell with:")
+		fmt.Println("  bash:  source <(speedrun-cli completion bash)")
+		fmt.Println("  zsh:   source <(speedrun-cli completion zsh)")
+		fmt.Println("  fish:  speedrun-cli completion fish | source")
+		os.Exit(1)
+	}
+	fmt.Print(completionScripts[args[0]])
+}
+
+// handleComplete prints the completions for the words typed so far; the
+// last word is the one being completed.
+func handleComplete(words []string) {
+	for _, candidate := range completionCandidates(words) {
+		fmt.Println(candidate)
+	}
+}
+
+func completionCandidates(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	args := words[:len(words)-1]
+	
+	var candidates []string
+	switch {
+	case strings.HasPrefix(current, "-"):
+		candidates = completionFlags()
+	case len(args) == 0:
+		candidates = completionSubcommands
+	case len(args) == 1:
+		switch args[0] {
+		case "leaderboard", "diff", "compare", "game-stats", "open", "queue", "submit", "sync":
+			candidates = completionGames()
+		case "config":
+			candidates = configSubcommands
+		case "completion":
+			candidates = []string{"bash", "fish", "zsh"}
+		}
+	case len(args) == 2 && (args[0] == "leaderboard" || args[0] == "diff" || args[0] == "compare" || args[0] == "feed" || args[0] == "overlay"):
+		candidates = completionCategories(args[1])
+	case len(args) == 2 && args[0] == "config":
+		switch args[1] {
+		case "get", "set", "unset":
+			for _, def := range settingDefinitions {
+				candidates = append(candidates, def.Key)
+			}
+		case "use":
+			if cfg, err := loadConfig(); err == nil {
+				for name := range cfg.Profiles {
+					candidates = append(candidates, name)
+				}
+			}
+		}
+	}
+	
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(current)) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+func completionFlags() []string {
+	flags := []string{"--profile", "--no-color", "--record", "--replay"}
+	for _, def := range settingDefinitions {
+		if def.Flag != "" {
+			flags = append(flags, def.Flag)
+		}
+	}
+	return flags
+}
+
+// knownGames collects games from bookmarks, recent games, and every game
+// response in the on-disk cache, without touching the network.
+func knownGames() []SavedGame {
+	games := append(loadBookmarks(), loadRecentGames()...)
+	
+	cache := newResponseCache(settings)
+	for _, entry := range cache.entries("/games") {
+		var response struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if json.Unmarshal(entry.Body, &response) != nil {
+			continue
+		}
+		
+		var list []Game
+		if json.Unmarshal(response.Data, &list) != nil {
+			var single Game
+			if json.Unmarshal(response.Data, &single) != nil {
+				continue
+			}
+			list = []Game{single}
+		}
+		
+		for i := range list {
+			if list[i].ID != "" && list[i].Abbreviation != "" {
+				games = append(games, newSavedGame(&list[i]))
+			}
+		}
+	}
+	return games
+}
+
+func completionGames() []string {
+	var abbreviations []string
+	seen := make(map[string]bool)
+	for _, game := range knownGames() {
+		if !seen[game.Abbreviation] {
+			seen[game.Abbreviation] = true
+			abbreviations = append(abbreviations, game.Abbreviation)
+		}
+	}
+	return abbreviations
+}
+
+// completionCategories lists a game's category names, with underscores for
+// spaces as in speedrun.com links. Cached categories are used even when
+// stale; the API is only asked when nothing is cached.
+func completionCategories(game string) []string {
+	gameID := ""
+	for _, known := range knownGames() {
+		if strings.EqualFold(known.Abbreviation, game) || known.ID == game {
+			gameID = known.ID
+			break
+		}
+	}
+	
+	// Completion runs inside a shell TAB, so a missing network must fail
+	// fast instead of retrying with backoff
+	api := NewSpeedrunAPI()
+	api.maxRetries = 0
+	api.timeout = CompletionTimeout
+	if gameID == "" {
+		fetched, err := api.GetGame(game)
+		if err != nil {
+			return nil
+		}
+		gameID = fetched.ID
+	}
+	
+	var categories []Category
+	for _, entry := range api.cache.entries(fmt.Sprintf("/games/%s/categories", gameID)) {
+		var response struct {
+			Data []Category `json:"data"`
+		}
+		if json.Unmarshal(entry.Body, &response) == nil && len(response.Data) > 0 {
+			categories = response.Data
+			break
+		}
+	}
+	if categories == nil {
+		var err error
+		if categories, err = api.GetGameCategories(gameID); err != nil {
+			return nil
+		}
+	}
+	
+	var names []string
+	for _, category := range categories {
+		names = append(names, strings.ReplaceAll(category.Name, " ", "_"))
+	}
+	return names
+}
@@ -0,0 +1,642 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ConfigDirName  = "speedrun-cli"
+	ConfigFileName = "config.json"
+	APIKeyFileName = "api-key"
+	APIKeyEnvVar   = "SPEEDRUN_API_KEY"
+	ProfileEnvVar  = "SPEEDRUN_PROFILE"
+)
+
+type settingKind int
+
+const (
+	stringSetting settingKind = iota
+	intSetting
+	boolSetting
+	durationSetting
+)
+
+// SettingDefinition describes one configurable value. A value comes from
+// the command-line flag, then the environment variable, then the active
+// profile, then the top level of config.json, then Default.
+type SettingDefinition struct {
+	Key         string
+	Kind        settingKind
+	Default     string
+	Env         string
+	Flag        string
+	Allowed     []string
+	Secret      bool
+	Description string
+}
+
+var settingDefinitions = []SettingDefinition{
+	{Key: "api_base", Default: DefaultAPIBase, Env: "SPEEDRUN_API_BASE", Flag: "--api-base",
+		Description: "speedrun.com API base URL"},
+	{Key: "api_key", Env: APIKeyEnvVar, Secret: true,
+		Description: "API key sent as X-API-Key (also read from the file written by 'login')"},
+	{Key: "timeout", Kind: durationSetting, Default: (DefaultTimeout * time.Second).String(), Env: "SPEEDRUN_TIMEOUT",
+		Description: "per-request timeout (0 disables)"},
+	{Key: "max_retries", Kind: intSetting, Default: strconv.Itoa(MaxRetries),
+		Description: "retries after rate limiting or server errors"},
+	{Key: "backoff_base", Kind: intSetting, Default: strconv.Itoa(BackoffBase),
+		Description: "first retry delay in seconds, doubled on each attempt"},
+	{Key: "rate_limit", Kind: intSetting, Default: strconv.Itoa(RequestsPerMinute),
+		Description: "most API requests per minute (0 disables)"},
+	{Key: "cache_ttl_games", Kind: durationSetting, Default: "24h",
+		Description: "how long games, categories, and variables are cached (0 disables)"},
+	{Key: "cache_ttl_leaderboards", Kind: durationSetting, Default: "5m",
+		Description: "how long leaderboards are cached (0 disables)"},
+	{Key: "cache_ttl_users", Kind: durationSetting, Default: "1h",
+		Description: "how long user lookups are cached (0 disables)"},
+	{Key: "timing_method", Flag: "--timing", Allowed: []string{"", "realtime", "realtime_noloads", "ingame"},
+		Description: "time shown for runs: realtime, realtime_noloads, or ingame (empty: the game's primary)"},
+	{Key: "page_size", Kind: intSetting, Default: "0", Flag: "--page-size",
+		Description: "runs per leaderboard page (0 fits the terminal)"},
+	{Key: "theme", Default: DefaultThemeName, Env: "SPEEDRUN_THEME", Flag: "--theme",
+		Description: "color theme (built-in or defined under \"themes\")"},
+	{Key: "offline", Kind: boolSetting, Default: "false", Env: "SPEEDRUN_OFFLINE", Flag: "--offline",
+		Description: "answer from snapshots taken with 'sync' instead of the network"},
+	{Key: "ascii", Kind: boolSetting, Default: "false", Flag: "--ascii",
+		Description: "replace emoji with plain text"},
+	{Key: "default_filter",
+		Description: "leaderboard filter applied when a board opens, e.g. \"video !emu\""},
+	{Key: "log_level", Default: "off", Env: "SPEEDRUN_LOG_LEVEL", Flag: "--log-level",
+		Allowed: []string{"off", "error", "warn", "info", "debug"},
+		Description: "log level: off, error, warn, info (one line per API request), or debug"},
+	{Key: "log_file", Env: "SPEEDRUN_LOG_FILE", Flag: "--log-file",
+		Description: "file logs are appended to (empty: stderr)"},
+	{Key: "log_format", Default: "text", Flag: "--log-format", Allowed: []string{"text", "json"},
+		Description: "log format: text or json"},
+	{Key: "debug", Kind: boolSetting, Default: "false", Env: "SPEEDRUN_DEBUG", Flag: "--debug",
+		Description: "shorthand for log_level debug"},
+	{Key: "trace", Kind: boolSetting, Default: "false", Env: "SPEEDRUN_TRACE", Flag: "--trace",
+		Description: "log request and response headers and bodies (implies debug)"},
+}
+
+func findSetting(key string) (SettingDefinition, bool) {
+	for _, def := range settingDefinitions {
+		if def.Key == key {
+			return def, true
+		}
+	}
+	return SettingDefinition{}, false
+}
+
+// validate checks that value parses as the setting's kind.
+func (def SettingDefinition) validate(value string) error {
+	var err error
+	switch def.Kind {
+	case intSetting:
+		var n int
+		if n, err = strconv.Atoi(value); err == nil && n < 0 {
+			err = fmt.Errorf("must not be negative")
+		}
+	case boolSetting:
+		_, err = parseBoolSetting(value)
+	case durationSetting:
+		_, err = parseDurationSetting(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: %v", value, def.Key, err)
+	}
+	
+	if len(def.Allowed) > 0 {
+		for _, allowed := range def.Allowed {
+			if value == allowed {
+				return nil
+			}
+		}
+		var choices []string
+		for _, allowed := range def.Allowed {
+			if allowed != "" {
+				choices = append(choices, allowed)
+			}
+		}
+		return fmt.Errorf("invalid value %q for %s (expected one of: %s)", value, def.Key, strings.Join(choices, ", "))
+	}
+	return nil
+}
+
+// jsonValue converts a validated value to the JSON type written to config.json.
+func (def SettingDefinition) jsonValue(value string) interface{} {
+	switch def.Kind {
+	case intSetting:
+		n, _ := strconv.Atoi(value)
+		return n
+	case boolSetting:
+		b, _ := parseBoolSetting(value)
+		return b
+	default:
+		return value
+	}
+}
+
+func parseBoolSetting(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return true, nil
+	case "", "0", "false", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected true or false")
+}
+
+// parseDurationSetting accepts Go durations ("90s", "1h30m") or a plain
+// number of seconds.
+func parseDurationSetting(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("must not be negative")
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a duration like 30s or 5m")
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
+// Config is the contents of config.json: top-level settings, named
+// profiles that override them, and custom color themes.
+type Config struct {
+	Profile  string
+	Values   map[string]string
+	Profiles map[string]map[string]string
+	Themes   map[string]map[string]string
+}
+
+// configDir returns $XDG_CONFIG_HOME/speedrun-cli, falling back to
+// ~/.config/speedrun-cli when XDG_CONFIG_HOME is unset.
+func configDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, ConfigDirName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", ConfigDirName)
+	}
+	return filepath.Join(home, ".config", ConfigDirName)
+}
+
+func configPath() string {
+	return filepath.Join(configDir(), ConfigFileName)
+}
+
+// readConfigFile returns config.json as a generic JSON object, or an empty
+// object when the file does not exist.
+func readConfigFile() (map[string]interface{}, error) {
+	raw := make(map[string]interface{})
+	
+	data, err := os.ReadFile(configPath())
+	if os.IsNotExist(err) {
+		return raw, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%s: %v", configPath(), err)
+	}
+	return raw, nil
+}
+
+func writeConfigFile(raw map[string]interface{}) error {
+	if err := os.MkdirAll(configDir(), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath(), append(data, '\n'), 0600)
+}
+
+func loadConfig() (Config, error) {
+	raw, err := readConfigFile()
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(raw)
+}
+
+func parseConfig(raw map[string]interface{}) (Config, error) {
+	cfg := Config{
+		Values:   make(map[string]string),
+		Profiles: make(map[string]map[string]string),
+	}
+	
+	for key, value := range raw {
+		switch key {
+		case "profile":
+			cfg.Profile = configValueString(value)
+		case "profiles":
+			profiles, ok := value.(map[string]interface{})
+			if !ok {
+				return cfg, fmt.Errorf("%s: \"profiles\" must be an object", configPath())
+			}
+			for name, profile := range profiles {
+				values, ok := profile.(map[string]interface{})
+				if !ok {
+					return cfg, fmt.Errorf("%s: profile %q must be an object", configPath(), name)
+				}
+				cfg.Profiles[name] = make(map[string]string)
+				for k, v := range values {
+					cfg.Profiles[name][k] = configValueString(v)
+				}
+			}
+		case "themes":
+			data, _ := json.Marshal(value)
+			if err := json.Unmarshal(data, &cfg.Themes); err != nil {
+				return cfg, fmt.Errorf("%s: \"themes\" must map theme names to color roles", configPath())
+			}
+		default:
+			cfg.Values[key] = configValueString(value)
+		}
+	}
+	
+	return cfg, nil
+}
+
+func configValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Settings holds the resolved value of every setting and where it came from.
+type Settings struct {
+	Profile string
+	values  map[string]string
+	sources map[string]string
+}
+
+// settings starts out as the defaults so that code running before
+// startup finishes (or in a broken config) still sees sane values.
+var settings, _ = resolveSettings(Config{}, nil, "")
+
+// resolveSettings layers flags, environment variables, the named profile,
+// and the config file over the defaults.
+func resolveSettings(cfg Config, flags map[string]string, profile string) (*Settings, error) {
+	s := &Settings{
+		Profile: profile,
+		values:  make(map[string]string),
+		sources: make(map[string]string),
+	}
+	
+	var profileValues map[string]string
+	if profile != "" {
+		var exists bool
+		if profileValues, exists = cfg.Profiles[profile]; !exists {
+			return s, fmt.Errorf("unknown profile %q", profile)
+		}
+	}
+	
+	var firstErr error
+	for _, def := range settingDefinitions {
+		value, source := def.Default, "default"
+		if v, ok := cfg.Values[def.Key]; ok {
+			value, source = v, "config"
+		}
+		if v, ok := profileValues[def.Key]; ok {
+			value, source = v, "profile "+profile
+		}
+		if def.Env != "" && os.Getenv(def.Env) != "" {
+			value, source = os.Getenv(def.Env), "env "+def.Env
+		}
+		if v, ok := flags[def.Key]; ok {
+			value, source = v, "flag "+def.Flag
+		}
+		
+		if err := def.validate(value); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%v (from %s)", err, source)
+			}
+			value, source = def.Default, "default"
+		}
+		s.values[def.Key] = value
+		s.sources[def.Key] = source
+	}
+	
+	return s, firstErr
+}
+
+func (s *Settings) String(key string) string {
+	return s.values[key]
+}
+
+func (s *Settings) Source(key string) string {
+	return s.sources[key]
+}
+
+func (s *Settings) Int(key string) int {
+	n, _ := strconv.Atoi(s.values[key])
+	return n
+}
+
+func (s *Settings) Bool(key string) bool {
+	b, _ := parseBoolSetting(s.values[key])
+	return b
+}
+
+func (s *Settings) Duration(key string) time.Duration {
+	d, _ := parseDurationSetting(s.values[key])
+	return d
+}
+
+// loadAPIKey resolves the API key from settings (environment, profile, or
+// config file), then the key file written by 'speedrun-cli login'.
+func loadAPIKey() string {
+	if key := strings.TrimSpace(settings.String("api_key")); key != "" {
+		return key
+	}
+	
+	data, err := os.ReadFile(filepath.Join(configDir(), APIKeyFileName))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func saveAPIKey(key string) error {
+	dir := configDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, APIKeyFileName), []byte(strings.TrimSpace(key)+"\n"), 0600)
+}
+
+func removeAPIKey() error {
+	err := os.Remove(filepath.Join(configDir(), APIKeyFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// handleConfig implements 'speedrun-cli config'. Changes go to the top
+// level of config.json, or to the profile named with --profile.
+func handleConfig(args []string) {
+	if len(args) == 0 {
+		printConfigUsage()
+		os.Exit(1)
+	}
+	
+	var err error
+	switch args[0] {
+	case "list":
+		listSettings()
+	case "get":
+		if len(args) != 2 {
+			printConfigUsage()
+			os.Exit(1)
+		}
+		if _, known := findSetting(args[1]); !known {
+			err = fmt.Errorf("unknown setting %q (see 'speedrun-cli config list')", args[1])
+			break
+		}
+		fmt.Println(settings.String(args[1]))
+	case "set":
+		if len(args) != 3 {
+			printConfigUsage()
+			os.Exit(1)
+		}
+		err = setConfigValue(args[1], args[2], globalOptions.Profile)
+	case "unset":
+		if len(args) != 2 {
+			printConfigUsage()
+			os.Exit(1)
+		}
+		err = unsetConfigValue(args[1], globalOptions.Profile)
+	case "use":
+		name := ""
+		if len(args) > 1 {
+			name = args[1]
+		}
+		err = useProfile(name)
+	case "profiles":
+		err = listProfiles()
+	case "path":
+		fmt.Println(configPath())
+	default:
+		printConfigUsage()
+		os.Exit(1)
+	}
+	
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func printConfigUsage() {
+	fmt.Println("Usage: speedrun-cli [--profile NAME] config <command>")
+	fmt.Println("  list               show every setting, its value, and where it came from")
+	fmt.Println("  get KEY            print a setting's effective value")
+	fmt.Println("  set KEY VALUE      store a setting (in the profile given with --profile)")
+	fmt.Println("  unset KEY          remove a stored setting")
+	fmt.Println("  use [PROFILE]      make a profile the default (no name clears it)")
+	fmt.Println("  profiles           list profiles")
+	fmt.Println("  path               print the config file location")
+}
+
+func listSettings() {
+	if settings.Profile != "" {
+		fmt.Printf("Profile: %s\n\n", settings.Profile)
+	}
+	
+	keys := make([]string, len(settingDefinitions))
+	values := make([]string, len(settingDefinitions))
+	sources := make([]string, len(settingDefinitions))
+	for i, def := range settingDefinitions {
+		keys[i] = def.Key
+		values[i] = settings.String(def.Key)
+		if def.Secret && values[i] != "" {
+			values[i] = maskSecret(values[i])
+		}
+		sources[i] = settings.Source(def.Key)
+	}
+	
+	columns := []TableColumn{
+		textColumn("Key", keys, 30, MinTextColumnWidth, 0),
+		textColumn("Value", values, 40, MinTextColumnWidth, 0),
+		textColumn("Source", sources, 30, MinTextColumnWidth, 0),
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	for i := range settingDefinitions {
+		if values[i] == "" {
+			values[i] = EmptyValuePlaceholder
+		}
+		fmt.Println(formatTableRow(columns, []string{keys[i], values[i], sources[i]}))
+	}
+	
+	fmt.Printf("\nConfig file: %s\n", configPath())
+}
+
+func maskSecret(value string) string {
+	if len(value) <= 4 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
+
+// configTarget returns the object in raw that holds settings for profile,
+// creating the profile when create is set.
+func configTarget(raw map[string]interface{}, profile string, create bool) (map[string]interface{}, error) {
+	if profile == "" {
+		return raw, nil
+	}
+	
+	profiles, _ := raw["profiles"].(map[string]interface{})
+	if profiles == nil {
+		if !create {
+			return nil, fmt.Errorf("unknown profile %q", profile)
+		}
+		profiles = make(map[string]interface{})
+		raw["profiles"] = profiles
+	}
+	
+	target, _ := profiles[profile].(map[string]interface{})
+	if target == nil {
+		if !create {
+			return nil, fmt.Errorf("unknown profile %q", profile)
+		}
+		target = make(map[string]interface{})
+		profiles[profile] = target
+	}
+	return target, nil
+}
+
+func setConfigValue(key, value, profile string) error {
+	def, known := findSetting(key)
+	if !known {
+		return fmt.Errorf("unknown setting %q (see 'speedrun-cli config list')", key)
+	}
+	if err := def.validate(value); err != nil {
+		return err
+	}
+	
+	raw, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	target, err := configTarget(raw, profile, true)
+	if err != nil {
+		return err
+	}
+	
+	target[key] = def.jsonValue(value)
+	if err := writeConfigFile(raw); err != nil {
+		return err
+	}
+	
+	if profile != "" {
+		fmt.Printf("Set %s in profile %s.\n", key, profile)
+	} else {
+		fmt.Printf("Set %s.\n", key)
+	}
+	return nil
+}
+
+func unsetConfigValue(key, profile string) error {
+	raw, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	target, err := configTarget(raw, profile, false)
+	if err != nil {
+		return err
+	}
+	
+	if _, exists := target[key]; !exists {
+		return fmt.Errorf("%s is not set", key)
+	}
+	delete(target, key)
+	if err := writeConfigFile(raw); err != nil {
+		return err
+	}
+	
+	fmt.Printf("Unset %s.\n", key)
+	return nil
+}
+
+func useProfile(name string) error {
+	raw, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	
+	if name == "" {
+		delete(raw, "profile")
+	} else {
+		if _, err := configTarget(raw, name, false); err != nil {
+			return err
+		}
+		raw["profile"] = name
+	}
+	if err := writeConfigFile(raw); err != nil {
+		return err
+	}
+	
+	if name == "" {
+		fmt.Println("Cleared the default profile.")
+	} else {
+		fmt.Printf("Using profile %s by default.\n", name)
+	}
+	return nil
+}
+
+func listProfiles() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Profiles) == 0 {
+		fmt.Println("No profiles defined. Create one with 'speedrun-cli --profile NAME config set KEY VALUE'.")
+		return nil
+	}
+	
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	for _, name := range names {
+		marker := " "
+		if name == settings.Profile {
+			marker = "*"
+		}
+		fmt.Printf("%s %s (%d settings)\n", marker, name, len(cfg.Profiles[name]))
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Leaderboards can be requested as they stood on a past date. A diff
+// joins a board at two dates by runner to show who is new, who moved, and
+// who dropped off, e.g. for a yearly recap.
+
+// leaderboardAsOf is the date leaderboards open at, set by
+// 'leaderboard --date'. Empty means today.
+var leaderboardAsOf string
+
+// DiffEntry is one runner on either side of a leaderboard diff. A zero
+// place means the runner was not on that board.
+type DiffEntry struct {
+	Runner      string
+	Before      *Run
+	After       *Run
+	BeforePlace int
+	AfterPlace  int
+}
+
+// parseAsOfDate validates a YYYY-MM-DD date for the leaderboard date
+// parameter. Empty input and "today" mean the current board.
+func parseAsOfDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "today") {
+		return "", nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+	}
+	if date.After(time.Now()) {
+		return "", fmt.Errorf("%s is in the future", value)
+	}
+	return value, nil
+}
+
+// asOfLabel names a board date for display.
+func asOfLabel(date string) string {
+	if date == "" {
+		return "today"
+	}
+	return date
+}
+
+func handleDiff(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	from := fs.String("from", "", "earlier date, YYYY-MM-DD (required)")
+	to := fs.String("to", "", "later date, YYYY-MM-DD (default today)")
+	subcategory := fs.String("subcategory", "", "subcategory label, required when the category has any")
+	top := fs.Int("top", 0, "only runners in the top N on either date (0 shows everyone)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli diff -from DATE [flags] <game> <category>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if fs.NArg() < 2 || *from == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	
+	fromDate, err := parseAsOfDate(*from)
+	if err == nil && fromDate == "" {
+		err = fmt.Errorf("-from must be a past date")
+	}
+	var toDate string
+	if err == nil {
+		toDate, err = parseAsOfDate(*to)
+	}
+	if err == nil && toDate != "" && toDate <= fromDate {
+		err = fmt.Errorf("-to must be after -from")
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	game, category, sub, err := resolveBoard(api, fs.Arg(0), strings.Join(fs.Args()[1:], " "), *subcategory)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	subcategoryID, title := "", game.Names.International+" - "+category.Name
+	if sub != nil {
+		subcategoryID = sub.ID
+		title += " (" + sub.Label + ")"
+	}
+	
+	before, err := loadBoardAsOf(api, game.ID, category.ID, subcategoryID, fromDate)
+	if err != nil {
+		fmt.Printf("Error loading the %s leaderboard: %v\n", asOfLabel(fromDate), err)
+		os.Exit(1)
+	}
+	after, err := loadBoardAsOf(api, game.ID, category.ID, subcategoryID, toDate)
+	if err != nil {
+		fmt.Printf("Error loading the %s leaderboard: %v\n", asOfLabel(toDate), err)
+		os.Exit(1)
+	}
+	
+	displayLeaderboardDiff(title, fromDate, toDate, topDiffEntries(diffLeaderboards(before, after), *top))
+}
+
+// loadBoardAsOf fetches a board at a date with every runner's name.
+func loadBoardAsOf(api *SpeedrunAPI, gameID, categoryID, subcategoryID, date string) (*Leaderboard, error) {
+	lb, err := api.GetLeaderboard(gameID, categoryID, "", subcategoryID, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.LoadPlayerNames(lb); err != nil {
+		return nil, err
+	}
+	return lb, nil
+}
+
+// showLeaderboardDiff compares the board on screen with the same board at
+// an earlier date.
+func showLeaderboardDiff(api *SpeedrunAPI, current *Leaderboard, gameID, categoryID, subcategoryID, title, date string) error {
+	if err := api.LoadPlayerNames(current); err != nil {
+		return err
+	}
+	before, err := loadBoardAsOf(api, gameID, categoryID, subcategoryID, date)
+	if err != nil {
+		return err
+	}
+	displayLeaderboardDiff(title, date, current.AsOf, diffLeaderboards(before, current))
+	return nil
+}
+
+// diffLeaderboards joins two boards by runner. Runners on the later board
+// come first in its order, followed by those who dropped off in their
+// earlier order.
+func diffLeaderboards(before, after *Leaderboard) []DiffEntry {
+	earlier := make(map[string]int)
+	for i, entry := range before.Runs {
+		if _, seen := earlier[runnerKey(entry.Run)]; !seen {
+			earlier[runnerKey(entry.Run)] = i
+		}
+	}
+	
+	var entries []DiffEntry
+	seen := make(map[string]bool)
+	for i := range after.Runs {
+		entry := &after.Runs[i]
+		key := runnerKey(entry.Run)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		
+		diff := DiffEntry{Runner: boardRunnerNames(entry.Run, after), After: &entry.Run, AfterPlace: entry.Place}
+		if j, ok := earlier[key]; ok {
+			diff.Before, diff.BeforePlace = &before.Runs[j].Run, before.Runs[j].Place
+		}
+		entries = append(entries, diff)
+	}
+	
+	for i := range before.Runs {
+		entry := &before.Runs[i]
+		key := runnerKey(entry.Run)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, DiffEntry{Runner: boardRunnerNames(entry.Run, before), Before: &entry.Run, BeforePlace: entry.Place})
+	}
+	return entries
+}
+
+// topDiffEntries keeps runners placed in the top n on either board.
+func topDiffEntries(entries []DiffEntry, n int) []DiffEntry {
+	if n <= 0 {
+		return entries
+	}
+	var kept []DiffEntry
+	for _, entry := range entries {
+		if (entry.AfterPlace > 0 && entry.AfterPlace <= n) || (entry.BeforePlace > 0 && entry.BeforePlace <= n) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// runnerKey identifies a run's runners across boards: user IDs, or guest
+// names for guests.
+func runnerKey(run Run) string {
+	keys := make([]string, len(run.Players))
+	for i, player := range run.Players {
+		if player.ID != "" {
+			keys[i] = player.ID
+		} else {
+			keys[i] = "guest:" + strings.ToLower(player.Name)
+		}
+	}
+	return strings.Join(keys, "+")
+}
+
+// boardRunnerNames names every runner of a run from the board's player
+// names, without a lookup per runner.
+func boardRunnerNames(run Run, lb *Leaderboard) string {
+	if len(run.Players) == 0 {
+		return "Guest"
+	}
+	names := make([]string, len(run.Players))
+	for i, player := range run.Players {
+		switch {
+		case player.Name != "":
+			names[i] = player.Name
+		case lb.PlayerMap[player.ID] != "":
+			names[i] = lb.PlayerMap[player.ID]
+		default:
+			names[i] = player.ID
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func displayLeaderboardDiff(title, from, to string, entries []DiffEntry) {
+	colors := theme.Colors
+	
+	fmt.Printf("\n%s%s: %s → %s\n", icon("📅"), title, asOfLabel(from), asOfLabel(to))
+	
+	var added, removed, up, down, improved int
+	for _, entry := range entries {
+		switch {
+		case entry.Before == nil:
+			added++
+		case entry.After == nil:
+			removed++
+		case entry.AfterPlace < entry.BeforePlace:
+			up++
+		case entry.AfterPlace > entry.BeforePlace:
+			down++
+		}
+		if entry.Before != nil && entry.After != nil && entry.After.ID != entry.Before.ID {
+			improved++
+		}
+	}
+	fmt.Printf("%s%d new, %d dropped off, %d moved up, %d moved down, %d new PBs\n\n",
+		icon("📈"), added, removed, up, down, improved)
+	
+	if len(entries) == 0 {
+		fmt.Println("No runs on either date.")
+		return
+	}
+	
+	runners := make([]string, len(entries))
+	for i, entry := range entries {
+		runners[i] = entry.Runner
+	}
+	columns := []TableColumn{
+		{Title: "Rank", Width: RankColumnWidth},
+		{Title: "Change", Width: 6},
+		textColumn("Runner", runners, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Time", Width: TimeColumnWidth},
+		{Title: "Was", Width: RankColumnWidth},
+		{Title: "Time then", Width: TimeColumnWidth},
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	
+	for i, entry := range entries {
+		rank, runTime, was, timeThen := EmptyValuePlaceholder, EmptyValuePlaceholder, EmptyValuePlaceholder, EmptyValuePlaceholder
+		if entry.After != nil {
+			rank = strings.TrimRight(formatRank(entry.AfterPlace, colors), " ")
+			runTime = getBestTime(*entry.After)
+		}
+		if entry.Before != nil {
+			was = fmt.Sprintf("%d", entry.BeforePlace)
+			timeThen = getBestTime(*entry.Before)
+		}
+		
+		fmt.Println(formatTableRow(columns, []string{rank, diffChange(entry, colors), runners[i], runTime, was, timeThen}))
+	}
+}
+
+// diffChange describes how a runner's place moved between the two dates.
+func diffChange(entry DiffEntry, colors Colors) string {
+	switch {
+	case entry.Before == nil:
+		return colors.Green + "NEW" + colors.Reset
+	case entry.After == nil:
+		return colors.Red + "OUT" + colors.Reset
+	case entry.AfterPlace < entry.BeforePlace:
+		return fmt.Sprintf("%s%s%d%s", colors.Green, symbol("▲", "+"), entry.BeforePlace-entry.AfterPlace, colors.Reset)
+	case entry.AfterPlace > entry.BeforePlace:
+		return fmt.Sprintf("%s%s%d%s", colors.Red, symbol("▼", "-"), entry.AfterPlace-entry.BeforePlace, colors.Reset)
+	default:
+		return "="
+	}
+}
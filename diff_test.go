@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// plantRun adds a copy of an existing run to the fake server's data with
+// the given fields replaced.
+func plantRun(t *testing.T, server *FakeServer, fromID string, changes fakeObject) {
+	t.Helper()
+	for _, run := range server.data.Runs {
+		if run["id"] != fromID {
+			continue
+		}
+		var planted fakeObject
+		data, _ := json.Marshal(run)
+		if err := json.Unmarshal(data, &planted); err != nil {
+			t.Fatal(err)
+		}
+		for key, value := range changes {
+			planted[key] = value
+		}
+		server.data.Runs = append(server.data.Runs, planted)
+		return
+	}
+	t.Fatalf("run %s not found", fromID)
+}
+
+func plantedTimes(pt string) fakeObject {
+	return fakeObject{"primary": pt, "primary_t": ptSeconds(pt), "realtime": pt, "realtime_t": ptSeconds(pt)}
+}
+
+// usBoardsAcrossTime loads sm64 120 Star (US) as it stood at the end of
+// 2021, then changes the board the way the years did before loading it as
+// of today: Marbler sets a new PB, ikori_o's run is rejected, and the guest
+// Mario, who was on the old board, returns as "mario" with a faster run.
+func usBoardsAcrossTime(t *testing.T) (before, after *Leaderboard) {
+	t.Helper()
+	api, server := startFakeAPI(t, fakeFaults{})
+	
+	plantRun(t, server, "r00049", fakeObject{"id": "rguest01", "players": []interface{}{fakeObject{"rel": "guest", "name": "Mario"}},
+		"date": "2019-01-01", "times": plantedTimes("PT1H56M")})
+	var err error
+	before, err = loadBoardAsOf(api, "o1y9wo6q", "wkpoo02r", "jq6540ol", "2021-12-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	plantRun(t, server, "r00049", fakeObject{"id": "rmarb002", "date": "2025-06-01", "times": plantedTimes("PT1H43M59S")})
+	plantRun(t, server, "r00049", fakeObject{"id": "rguest02", "players": []interface{}{fakeObject{"rel": "guest", "name": "mario"}},
+		"date": "2024-06-01", "times": plantedTimes("PT1H50M")})
+	for _, run := range server.data.Runs {
+		if run["id"] == "r00048" {
+			run["status"] = fakeObject{"status": "rejected", "reason": "Wrong version"}
+		}
+	}
+	after, err = loadBoardAsOf(api, "o1y9wo6q", "wkpoo02r", "jq6540ol", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return before, after
+}
+
+func TestDiffLeaderboardsAcrossTime(t *testing.T) {
+	before, after := usBoardsAcrossTime(t)
+	
+	// Runner, place then, place now; runners who dropped off come last
+	want := []string{
+		"Marbler 2 1", "cheese 0 2", "Dwhatever 0 3", "Bubzia 0 4", "Mekaniak 3 5", "mario 7 6", "Kalvin 0 7",
+		"Shizzal 4 8", "azure 0 9", "coolguy42 5 10", "Yaiba 0 11", "ChocoPie 6 12", "ikori_o 1 0",
+	}
+	entries := diffLeaderboards(before, after)
+	var got []string
+	for _, entry := range entries {
+		got = append(got, fmt.Sprintf("%s %d %d", entry.Runner, entry.BeforePlace, entry.AfterPlace))
+		if (entry.Before == nil) != (entry.BeforePlace == 0) || (entry.After == nil) != (entry.AfterPlace == 0) {
+			t.Errorf("%s: runs and places disagree: %+v", entry.Runner, entry)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("diffLeaderboards() =\n%q\nwant\n%q", got, want)
+	}
+	
+	// Both sides keep the run that stood on that date
+	for _, check := range []struct {
+		entry         DiffEntry
+		before, after string
+	}{
+		{entries[0], "r00049", "rmarb002"},
+		{entries[4], "r00051", "r00051"},
+		{entries[5], "rguest01", "rguest02"},
+	} {
+		if check.entry.Before.ID != check.before || check.entry.After.ID != check.after {
+			t.Errorf("%s: runs %s → %s, want %s → %s", check.entry.Runner, check.entry.Before.ID, check.entry.After.ID, check.before, check.after)
+		}
+	}
+}
+
+func TestTopDiffEntries(t *testing.T) {
+	before, after := usBoardsAcrossTime(t)
+	entries := diffLeaderboards(before, after)
+	
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{n: 1, want: []string{"Marbler", "ikori_o"}},
+		{n: 3, want: []string{"Marbler", "cheese", "Dwhatever", "Mekaniak", "ikori_o"}},
+		{n: 7, want: []string{"Marbler", "cheese", "Dwhatever", "Bubzia", "Mekaniak", "mario", "Kalvin", "Shizzal", "coolguy42", "ChocoPie", "ikori_o"}},
+	}
+	
+	for _, tt := range tests {
+		var got []string
+		for _, entry := range topDiffEntries(entries, tt.n) {
+			got = append(got, entry.Runner)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("topDiffEntries(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+	if got := topDiffEntries(entries, 0); len(got) != len(entries) {
+		t.Errorf("topDiffEntries(0) kept %d of %d entries", len(got), len(entries))
+	}
+}
+
+func TestDisplayLeaderboardDiff(t *testing.T) {
+	before, after := usBoardsAcrossTime(t)
+	plainOutput(t, 100, 0)
+	
+	out := captureStdout(t, func() {
+		displayLeaderboardDiff("Super Mario 64 - 120 Star (US)", "2021-12-31", "", topDiffEntries(diffLeaderboards(before, after), 3))
+	})
+	
+	for _, want := range []string{
+		"Super Mario 64 - 120 Star (US): 2021-12-31 → today",
+		"2 new, 1 dropped off, 1 moved up, 1 moved down, 1 new PBs",
+		"▲1     Marbler   1:43:59         2     1:47:13",
+		"NEW    cheese    1:44:10         —     —",
+		"▼2     Mekaniak  1:49:15         3     1:49:15",
+		"OUT    ikori_o   —               1     1:46:12",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff output is missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -2,22 +2,35 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
-func displayLeaderboard(lb *Leaderboard, page int) int {
-	colors := DefaultColors
-	
-	fmt.Printf("\n🏆 %s - %s\n", lb.Game.Data.Names.International, lb.Category.Data.Name)
-	fmt.Printf("📊 %s\n\n", lb.Weblink)
+// displayLeaderboard prints one page of the leaderboard and returns the
+// page count. highlight is an index into lb.Runs, or -1 for none.
+func displayLeaderboard(lb *Leaderboard, page int, highlight int) int {
+	colors := theme.Colors
+	
+	fmt.Printf("\n%s%s - %s\n", icon("🏆"), lb.Game.Data.Names.International, lb.Category.Data.Name)
+	fmt.Printf("%s%s\n", icon("📊"), lb.Weblink)
+	if lb.AsOf != "" {
+		fmt.Printf("%sAs of %s\n", icon("📅"), lb.AsOf)
+	}
+	if lb.Filter != "" {
+		fmt.Printf("%sFilter: %s (%d of %d runs)\n", icon("🔎"), lb.Filter, len(lb.Runs), lb.TotalRuns)
+	}
+	fmt.Println()
 	
 	if len(lb.Runs) == 0 {
+		if lb.Filter != "" {
+			fmt.Println("No runs match the current filter. Type 'clear' to remove it.")
+			return 0
+		}
 		fmt.Println("No runs found for this category.")
 		return 0
 	}
 	
-	// Pagination constants
-	const pageSize = 25
+	pageSize := leaderboardPageSize()
 	totalRuns := len(lb.Runs)
 	totalPages := (totalRuns + pageSize - 1) / pageSize
 	
@@ -45,55 +58,50 @@ func displayLeaderboard(lb *Leaderboard, page int) int {
 	comments := make([]string, len(pageRuns))
 	
 	for i, entry := range pageRuns {
-		playerNames[i] = getPlayerDisplayName(entry.Run)
+		playerNames[i] = leaderboardPlayerName(entry.Run, lb)
 		platforms[i] = getPlatformName(entry.Run, lb.PlatformMap)
 		comments[i] = cleanComment(entry.Run.Comment)
 	}
 	
-	playerWidth := calculateDynamicWidth(playerNames, 25)
-	platformWidth := calculateDynamicWidth(platforms, 20)
-	commentWidth := calculateDynamicWidth(comments, 30)
-	
-	headerFormat := fmt.Sprintf("%%-%ds%%-%ds %%-15s %%-%ds %%-10s %%-5s %%-3s %%s\n", 
-		6, playerWidth, platformWidth)
-	rowFormat := fmt.Sprintf("%%s%%-%ds %%-15s %%-%ds %%-10s %%-5s %%-3s %%s\n", 
-		playerWidth, platformWidth)
+	columns := []TableColumn{
+		{Title: "Rank", Width: RankColumnWidth},
+		textColumn("Player", playerNames, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Time", Width: TimeColumnWidth},
+		textColumn("Platform", platforms, PlatformColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Date", Width: DateColumnWidth},
+		{Title: "Video", Width: VideoColumnWidth, DropRank: 1},
+		{Title: "Emu", Width: EmuColumnWidth, DropRank: 2},
+		textColumn("Comment", comments, CommentColumnMaxWidth, MinCommentColumnWidth, 3),
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
 	
-	fmt.Printf(headerFormat, "Rank", "Player", "Time", "Platform", "Date", "Video", "Emu", "Comment")
-	fmt.Println(strings.Repeat("─", 6+playerWidth+15+platformWidth+10+5+3+commentWidth+8))
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
 	
-	for _, entry := range pageRuns {
-		playerName := getPlayerDisplayName(entry.Run)
-		
-		time := getBestTime(entry.Run)
-		platform := getPlatformName(entry.Run, lb.PlatformMap)
-		
-		hasVideo := "❌"
-		if len(entry.Run.Videos.Links) > 0 && entry.Run.Videos.Links[0].URI != "" {
-			hasVideo = "✅"
-		}
-		
-		emulated := "❌"
-		if entry.Run.System.Emulated {
-			emulated = "✅"
-		}
-		
-		comment := cleanComment(entry.Run.Comment)
+	for i, entry := range pageRuns {
+		hasVideo := checkMark(len(entry.Run.Videos.Links) > 0 && entry.Run.Videos.Links[0].URI != "")
 		
-		rank := formatRank(entry.Place, colors)
+		emulated := checkMark(entry.Run.System.Emulated)
 		
-		fmt.Printf(rowFormat,
-			rank,
-			truncateString(playerName, playerWidth),
-			time,
-			truncateString(platform, platformWidth),
+		row := formatTableRow(columns, []string{
+			strings.TrimRight(formatRank(entry.Place, colors), " "),
+			playerNames[i],
+			getBestTime(entry.Run),
+			platforms[i],
 			entry.Run.Date,
 			hasVideo,
 			emulated,
-			truncateString(comment, commentWidth))
+			comments[i],
+		}) + "\n"
+		
+		if startIdx+i == highlight {
+			row = highlightRow(row, colors)
+		}
+		fmt.Print(row)
 	}
 	
-	fmt.Printf("\n📈 Page %d/%d (Showing %d-%d of %d runs)\n", page, totalPages, startIdx+1, endIdx, totalRuns)
+	fmt.Printf("\n%sPage %d/%d (Showing %d-%d of %d runs)\n", icon("📈"), page, totalPages, startIdx+1, endIdx, totalRuns)
 	
 	return totalPages
 }
@@ -119,13 +127,27 @@ func getPlayerDisplayName(run Run) string {
 	return "Guest"
 }
 
-func getBestTime(run Run) string {
-	times := []string{
-		run.Times.Primary,
-		run.Times.Realtime,
-		run.Times.RealtimeNoLoads,
-		run.Times.Ingame,
+// leaderboardPlayerName prefers names loaded with the leaderboard over a
+// per-runner user lookup.
+func leaderboardPlayerName(run Run, lb *Leaderboard) string {
+	if len(run.Players) > 0 && run.Players[0].ID != "" {
+		if name, exists := lb.PlayerMap[run.Players[0].ID]; exists && name != "" {
+			return name
+		}
 	}
+	return getPlayerDisplayName(run)
+}
+
+// highlightRow renders a table row in reverse video, re-applying the
+// highlight after any color resets inside the row.
+func highlightRow(row string, colors Colors) string {
+	row = strings.TrimSuffix(row, "\n")
+	row = strings.ReplaceAll(row, colors.Reset, colors.Reset+colors.Highlight)
+	return colors.Highlight + row + colors.Reset + "\n"
+}
+
+func getBestTime(run Run) string {
+	times := preferredTimes(run.Times.Primary, run.Times.Realtime, run.Times.RealtimeNoLoads, run.Times.Ingame)
 	
 	for _, timeStr := range times {
 		formatted := formatTime(timeStr)
@@ -137,6 +159,17 @@ func getBestTime(run Run) string {
 	return EmptyValuePlaceholder
 }
 
+// getBestSeconds is the time getBestTime shows, in seconds, or 0 when the
+// run has none.
+func getBestSeconds(run Run) float64 {
+	for _, timeStr := range preferredTimes(run.Times.Primary, run.Times.Realtime, run.Times.RealtimeNoLoads, run.Times.Ingame) {
+		if formatTime(timeStr) != EmptyValuePlaceholder {
+			return ptSeconds(timeStr)
+		}
+	}
+	return 0
+}
+
 func getPlatformName(run Run, platformMap map[string]string) string {
 	if run.System.Platform == "" {
 		return "Unknown"
@@ -152,21 +185,21 @@ func getPlatformName(run Run, platformMap map[string]string) string {
 func formatRank(place int, colors Colors) string {
 	switch place {
 	case 1:
-		return fmt.Sprintf("%s🥇%s    ", colors.Gold, colors.Reset)
+		return fmt.Sprintf("%s%s%s    ", colors.Gold, symbol("🥇", "[1]"), colors.Reset)
 	case 2:
-		return fmt.Sprintf("%s🥈%s    ", colors.Silver, colors.Reset)
+		return fmt.Sprintf("%s%s%s    ", colors.Silver, symbol("🥈", "[2]"), colors.Reset)
 	case 3:
-		return fmt.Sprintf("%s🥉%s    ", colors.Bronze, colors.Reset)
+		return fmt.Sprintf("%s%s%s    ", colors.Bronze, symbol("🥉", "[3]"), colors.Reset)
 	default:
 		return fmt.Sprintf("%-6d", place)
 	}
 }
 
 func displayUserRuns(user *User, runs []UserRun) {
-	colors := DefaultColors
+	colors := theme.Colors
 	
-	fmt.Printf("\n👤 %s - Recent Submitted Runs\n", user.Names.International)
-	fmt.Printf("📊 Showing %d verified runs\n\n", len(runs))
+	fmt.Printf("\n%s%s - Recent Submitted Runs\n", icon("👤"), user.Names.International)
+	fmt.Printf("%sShowing %d verified runs\n\n", icon("📊"), len(runs))
 	
 	if len(runs) == 0 {
 		fmt.Println("No verified runs found for this user.")
@@ -183,68 +216,56 @@ func displayUserRuns(user *User, runs []UserRun) {
 		comments[i] = cleanComment(run.Comment)
 	}
 	
-	gameWidth := calculateDynamicWidth(gameNames, 25)
-	categoryWidth := calculateDynamicWidth(categoryNames, 20)
-	commentWidth := calculateDynamicWidth(comments, 25)
-	
-	headerFormat := fmt.Sprintf("%%-%ds%%-%ds %%-15s %%-%ds %%-10s %%-6s %%-5s %%-3s %%s\n", 
-		6, gameWidth, categoryWidth)
-	rowFormat := fmt.Sprintf("%%s%%-%ds %%-15s %%-%ds %%-10s %%-6s %%-5s %%-3s %%s\n", 
-		gameWidth, categoryWidth)
+	columns := []TableColumn{
+		{Title: "Place", Width: RankColumnWidth},
+		textColumn("Game", gameNames, GameColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Time", Width: TimeColumnWidth},
+		textColumn("Category", categoryNames, CategoryColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Date", Width: DateColumnWidth},
+		{Title: "Status", Width: StatusColumnWidth},
+		{Title: "Video", Width: VideoColumnWidth, DropRank: 1},
+		{Title: "Emu", Width: EmuColumnWidth, DropRank: 2},
+		textColumn("Comment", comments, CommentMaxWidth, MinCommentColumnWidth, 3),
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
 	
-	fmt.Printf(headerFormat, "Place", "Game", "Time", "Category", "Date", "Status", "Video", "Emu", "Comment")
-	fmt.Println(strings.Repeat("─", 6+gameWidth+15+categoryWidth+10+6+5+3+commentWidth+9))
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
 	
-	for _, run := range runs {
-		gameName := run.Game.Names.International
-		categoryName := run.Category.Name
-		
-		time := getUserRunTime(run)
-		
-		hasVideo := "❌"
-		if len(run.Videos.Links) > 0 && run.Videos.Links[0].URI != "" {
-			hasVideo = "✅"
-		}
-		
-		emulated := "❌"
-		if run.System.Emulated {
-			emulated = "✅"
-		}
+	for i, run := range runs {
+		hasVideo := checkMark(len(run.Videos.Links) > 0 && run.Videos.Links[0].URI != "")
 		
-		comment := cleanComment(run.Comment)
+		emulated := checkMark(run.System.Emulated)
 		
 		var rank string
 		var status string
 		if run.Place > 0 {
-			rank = formatRank(run.Place, colors)
+			rank = strings.TrimRight(formatRank(run.Place, colors), " ")
 			status = fmt.Sprintf("#%d", run.Place)
 		} else {
-			rank = "    - "
+			rank = "   -"
 			status = "Unranked"
 		}
 		
-		fmt.Printf(rowFormat,
+		fmt.Println(formatTableRow(columns, []string{
 			rank,
-			truncateString(gameName, gameWidth),
-			time,
-			truncateString(categoryName, categoryWidth),
+			gameNames[i],
+			getUserRunTime(run),
+			categoryNames[i],
 			run.Date,
 			status,
 			hasVideo,
 			emulated,
-			truncateString(comment, commentWidth))
+			comments[i],
+		}))
 	}
 	
-	fmt.Printf("\n📈 Showing %d runs\n", len(runs))
+	fmt.Printf("\n%sShowing %d runs\n", icon("📈"), len(runs))
 }
 
 func getUserRunTime(run UserRun) string {
-	times := []string{
-		run.Times.Primary,
-		run.Times.Realtime,
-		run.Times.RealtimeNoLoads,
-		run.Times.Ingame,
-	}
+	times := preferredTimes(run.Times.Primary, run.Times.Realtime, run.Times.RealtimeNoLoads, run.Times.Ingame)
 	
 	for _, timeStr := range times {
 		formatted := formatTime(timeStr)
@@ -254,4 +275,366 @@ func getUserRunTime(run UserRun) string {
 	}
 	
 	return EmptyValuePlaceholder
-}
\ No newline at end of file
+}
+
+func displayQueue(game *Game, runs []Run, categoryNames map[string]string, placements map[string]QueuePlacement) {
+	fmt.Printf("\n%s%s - Verification Queue\n", icon("🛡️"), game.Names.International)
+	fmt.Printf("%s%d runs awaiting verification (oldest first)\n\n", icon("📊"), len(runs))
+	
+	if len(runs) == 0 {
+		fmt.Println("The queue is empty.")
+		return
+	}
+	
+	playerNames := make([]string, len(runs))
+	categories := make([]string, len(runs))
+	videos := make([]string, len(runs))
+	
+	for i, run := range runs {
+		playerNames[i] = getPlayerDisplayName(run)
+		categories[i] = queueCategoryName(run, categoryNames)
+		videos[i] = EmptyValuePlaceholder
+		if hasVideo(run) {
+			videos[i] = run.Videos.Links[0].URI
+		}
+	}
+	
+	// Video links are dropped rather than truncated, so the ones shown
+	// stay usable
+	columns := []TableColumn{
+		{Title: "#", Width: RankColumnWidth},
+		{Title: "Age", Width: AgeColumnWidth},
+		textColumn("Runner", playerNames, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		textColumn("Category", categories, LevelCategoryColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Time", Width: TimeColumnWidth},
+		{Title: "Would Place", Width: PlacementColumnWidth, DropRank: 1},
+		{Title: "Video", Width: calculateDynamicWidth(videos, math.MaxInt), DropRank: 2},
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	
+	for i, run := range runs {
+		fmt.Println(formatTableRow(columns, []string{
+			fmt.Sprintf("%d.", i+1),
+			formatAge(run.Submitted),
+			playerNames[i],
+			categories[i],
+			getBestTime(run),
+			formatPlacement(placements[run.ID]),
+			videos[i],
+		}))
+	}
+	
+	fmt.Printf("\n%sShowing %d queued runs\n", icon("📈"), len(runs))
+}
+
+func queueCategoryName(run Run, categoryNames map[string]string) string {
+	name := categoryNames[run.Category]
+	if name == "" {
+		name = run.Category
+	}
+	if run.Level != "" {
+		levelName := categoryNames[run.Level]
+		if levelName == "" {
+			levelName = run.Level
+		}
+		name = levelName + ": " + name
+	}
+	return name
+}
+
+func formatPlacement(placement QueuePlacement) string {
+	if !placement.Checked {
+		return EmptyValuePlaceholder
+	}
+	if !placement.IsPB {
+		return fmt.Sprintf("#%d (no PB)", placement.Place)
+	}
+	return fmt.Sprintf("#%d", placement.Place)
+}
+
+func displayRunDetails(run Run, categoryNames map[string]string, placement QueuePlacement) {
+	fmt.Printf("\n%sRun by %s\n", icon("🏃"), getPlayerDisplayName(run))
+	fmt.Println(horizontalRule(40))
+	fmt.Printf("Category:    %s\n", queueCategoryName(run, categoryNames))
+	fmt.Printf("Time:        %s\n", getBestTime(run))
+	if placement.Checked {
+		fmt.Printf("Would place: %s\n", formatPlacement(placement))
+	}
+	fmt.Printf("Played:      %s\n", run.Date)
+	fmt.Printf("Submitted:   %s (%s)\n", run.Submitted.Format("2006-01-02 15:04"), formatAge(run.Submitted))
+	fmt.Printf("Status:      %s\n", run.Status.Status)
+	
+	if len(run.Videos.Links) > 0 {
+		for _, link := range run.Videos.Links {
+			fmt.Printf("Video:       %s\n", link.URI)
+		}
+	} else {
+		fmt.Printf("Video:       %s\n", EmptyValuePlaceholder)
+	}
+	
+	fmt.Printf("Comment:     %s\n", cleanComment(run.Comment))
+	fmt.Printf("%s%s\n", icon("🔗"), run.Weblink)
+}
+
+func displayNotifications(notifications []Notification) {
+	unread := countUnread(notifications)
+	
+	fmt.Printf("\n%sNotifications\n", icon("🔔"))
+	fmt.Printf("%s%d notifications, %d unread\n\n", icon("📊"), len(notifications), unread)
+	
+	if len(notifications) == 0 {
+		fmt.Println("No notifications.")
+		return
+	}
+	
+	rowFormat := "%-5s%-2s %-9s %-11s %s\n"
+	fmt.Printf(rowFormat, "#", "", "Age", "Type", "Notification")
+	fmt.Println(horizontalRule(5+2+9+11+60+3))
+	
+	for i, notification := range notifications {
+		marker := " "
+		if notification.IsUnread() {
+			marker = symbol("●", "*")
+		}
+		
+		fmt.Printf(rowFormat,
+			fmt.Sprintf("%d.", i+1),
+			marker,
+			formatAge(notification.Created),
+			notification.Kind(),
+			truncateString(cleanComment(notification.Text), 60))
+	}
+	
+	fmt.Printf("\n%sShowing %d notifications (%s = unread)\n", icon("📈"), len(notifications), symbol("●", "*"))
+}
+
+func displayNotificationDetails(notification Notification) {
+	status := "read"
+	if notification.IsUnread() {
+		status = "unread"
+	}
+	
+	fmt.Printf("\n%s%s (%s)\n", icon("🔔"), notification.Kind(), status)
+	fmt.Println(horizontalRule(40))
+	fmt.Printf("%s\n", cleanComment(notification.Text))
+	fmt.Printf("Received: %s (%s)\n", notification.Created.Format("2006-01-02 15:04"), formatAge(notification.Created))
+	
+	if notification.Item.URI != "" {
+		fmt.Printf("%s%s\n", icon("🔗"), notification.Item.URI)
+	}
+}
+
+func countUnread(notifications []Notification) int {
+	unread := 0
+	for _, notification := range notifications {
+		if notification.IsUnread() {
+			unread++
+		}
+	}
+	return unread
+}
+
+func displaySeriesOverview(series *Series, entries []SeriesEntry) {
+	fmt.Printf("\n%s%s - Series Overview\n", icon("🎮"), series.Names.International)
+	fmt.Printf("%s%s\n\n", icon("📊"), series.Weblink)
+	
+	if len(entries) == 0 {
+		fmt.Println("No games found in this series.")
+		return
+	}
+	
+	gameNames := make([]string, len(entries))
+	categoryNames := make([]string, len(entries))
+	holders := make([]string, len(entries))
+	
+	for i, entry := range entries {
+		gameNames[i] = entry.Game.Names.International
+		categoryNames[i] = entry.Category.Name
+		if entry.Record != nil {
+			holders[i] = getPlayerDisplayName(*entry.Record)
+		}
+	}
+	
+	columns := []TableColumn{
+		{Title: "#", Width: RankColumnWidth},
+		textColumn("Game", gameNames, GameColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Year", Width: YearColumnWidth, DropRank: 1},
+		textColumn("Category", categoryNames, CategoryColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Runners", Width: RunnersColumnWidth, DropRank: 2},
+		{Title: "WR", Width: TimeColumnWidth},
+		textColumn("Holder", holders, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Date", Width: DateColumnWidth, DropRank: 3},
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	
+	for i, entry := range entries {
+		category, runners, record, holder, date := EmptyValuePlaceholder, "0", EmptyValuePlaceholder, EmptyValuePlaceholder, EmptyValuePlaceholder
+		if entry.Record != nil {
+			category = categoryNames[i]
+			runners = fmt.Sprintf("%d", entry.Runners)
+			record = getBestTime(*entry.Record)
+			holder = holders[i]
+			date = entry.Record.Date
+		}
+		
+		fmt.Println(formatTableRow(columns, []string{
+			fmt.Sprintf("%d.", i+1),
+			gameNames[i],
+			fmt.Sprintf("%d", entry.Game.Released),
+			category,
+			runners,
+			record,
+			holder,
+			date,
+		}))
+	}
+	
+	fmt.Printf("\n%sShowing %d games (most popular full-game category per game)\n", icon("📈"), len(entries))
+}
+
+func displayGameOverview(overview *GameOverview) {
+	game := overview.Game
+	
+	fmt.Printf("\n%s%s (%s)\n", icon("🎮"), game.Names.International, game.Abbreviation)
+	if game.Names.Japanese != "" {
+		fmt.Printf("   %s\n", game.Names.Japanese)
+	}
+	fmt.Printf("%s%s\n\n", icon("📊"), game.Weblink)
+	
+	released := fmt.Sprintf("%d", game.Released)
+	if game.ReleaseDate != "" {
+		released = fmt.Sprintf("%d (%s)", game.Released, game.ReleaseDate)
+	}
+	
+	fmt.Printf("Released:    %s\n", released)
+	fmt.Printf("Platforms:   %s\n", joinOrPlaceholder(overview.Platforms))
+	fmt.Printf("Regions:     %s\n", joinOrPlaceholder(overview.Regions))
+	fmt.Printf("Genres:      %s\n", joinOrPlaceholder(overview.Genres))
+	fmt.Printf("Developers:  %s\n", joinOrPlaceholder(overview.Developers))
+	fmt.Printf("Publishers:  %s\n", joinOrPlaceholder(overview.Publishers))
+	fmt.Printf("Engine:      %s\n", joinOrPlaceholder(overview.Engines))
+	fmt.Printf("Moderators:  %s\n", joinOrPlaceholder(overview.Moderators))
+	
+	ruleset := game.Ruleset
+	timing := make([]string, len(ruleset.RunTimes))
+	for i, method := range ruleset.RunTimes {
+		timing[i] = timingMethodName(method)
+		if method == ruleset.DefaultTime {
+			timing[i] += " (default)"
+		}
+	}
+	
+	fmt.Printf("\n%sRuleset\n", icon("📜"))
+	fmt.Printf("Timing:      %s\n", joinOrPlaceholder(timing))
+	fmt.Printf("Video:       %s\n", yesNo(ruleset.RequireVideo, "required", "not required"))
+	fmt.Printf("Emulators:   %s\n", yesNo(ruleset.EmulatorsAllowed, "allowed", "not allowed"))
+	fmt.Printf("Verified:    %s\n", yesNo(ruleset.RequireVerification, "runs require verification", "runs are auto-verified"))
+	
+	fmt.Printf("\n%sCategories\n", icon("🏷️"))
+	
+	if len(overview.Categories) == 0 {
+		fmt.Println("No categories found.")
+		return
+	}
+	
+	categoryNames := make([]string, len(overview.Categories))
+	holders := make([]string, len(overview.Categories))
+	for i, summary := range overview.Categories {
+		categoryNames[i] = summary.Category.Name
+		if summary.Record != nil {
+			holders[i] = getPlayerDisplayName(*summary.Record)
+		}
+	}
+	
+	columns := []TableColumn{
+		{Title: "#", Width: RankColumnWidth},
+		textColumn("Category", categoryNames, CategoryColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Type", Width: CategoryTypeColumnWidth, DropRank: 1},
+		{Title: "Runners", Width: RunnersColumnWidth, DropRank: 2},
+		{Title: "WR", Width: TimeColumnWidth},
+		textColumn("Holder", holders, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Date", Width: DateColumnWidth, DropRank: 3},
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	
+	for i, summary := range overview.Categories {
+		runners, record, holder, date := EmptyValuePlaceholder, EmptyValuePlaceholder, EmptyValuePlaceholder, EmptyValuePlaceholder
+		if summary.Category.Type == "per-game" {
+			runners = fmt.Sprintf("%d", summary.Runners)
+		}
+		if summary.Record != nil {
+			record = getBestTime(*summary.Record)
+			holder = holders[i]
+			date = summary.Record.Date
+		}
+		
+		fmt.Println(formatTableRow(columns, []string{
+			fmt.Sprintf("%d.", i+1),
+			categoryNames[i],
+			summary.Category.Type,
+			runners,
+			record,
+			holder,
+			date,
+		}))
+	}
+}
+
+func joinOrPlaceholder(items []string) string {
+	if len(items) == 0 {
+		return EmptyValuePlaceholder
+	}
+	return strings.Join(items, ", ")
+}
+
+func yesNo(value bool, yes, no string) string {
+	if value {
+		return yes
+	}
+	return no
+}
+
+// RulesSection is one titled block of rules text in the rules viewer.
+type RulesSection struct {
+	Title string
+	Rules string
+}
+
+func displayRules(title string, sections []RulesSection) {
+	colors := theme.Colors
+	width, _ := terminalDimensions()
+	
+	lines := []string{"", icon("📜") + title, horizontalRule(min(width, 60))}
+	for _, section := range sections {
+		lines = append(lines, "", colors.Bold+section.Title+colors.Reset)
+		if strings.TrimSpace(section.Rules) == "" {
+			lines = append(lines, "No rules provided.")
+			continue
+		}
+		lines = append(lines, renderMarkdown(section.Rules, width, colors)...)
+	}
+	
+	pageOutput(lines)
+}
+
+func categoryRulesSections(category *Category, subCategories []SubCategory) []RulesSection {
+	sections := []RulesSection{{Title: category.Name, Rules: category.Rules}}
+	for _, subCategory := range subCategories {
+		if strings.TrimSpace(subCategory.Rules) != "" {
+			sections = append(sections, RulesSection{Title: category.Name + " - " + subCategory.Label, Rules: subCategory.Rules})
+		}
+	}
+	return sections
+}
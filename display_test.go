@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// plainOutput turns off colors and pins the terminal size for the length
+// of a test, so output does not depend on where the tests run. Zero rows
+// means the height is unknown.
+func plainOutput(t *testing.T, cols, rows int) {
+	t.Helper()
+	savedTheme := theme
+	theme = Theme{Colors: Colors{}}
+	
+	terminalCache.Lock()
+	cols0, rows0, ok0, valid0 := terminalCache.cols, terminalCache.rows, terminalCache.ok, terminalCache.valid
+	terminalCache.cols, terminalCache.rows, terminalCache.ok, terminalCache.valid = cols, rows, true, true
+	terminalCache.Unlock()
+	
+	t.Cleanup(func() {
+		theme = savedTheme
+		terminalCache.Lock()
+		terminalCache.cols, terminalCache.rows, terminalCache.ok, terminalCache.valid = cols0, rows0, ok0, valid0
+		terminalCache.Unlock()
+	})
+}
+
+// captureStdout returns what fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = writer
+	
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(reader)
+		done <- out
+	}()
+	
+	defer func() { os.Stdout = saved }()
+	fn()
+	writer.Close()
+	return string(<-done)
+}
+
+// scriptInput feeds input to prompts for the length of a test, as if it
+// were piped to stdin.
+func scriptInput(t *testing.T, input string) {
+	t.Helper()
+	saved := lineEditor
+	lineEditor = &LineEditor{reader: bufio.NewReader(strings.NewReader(input)), history: &History{}}
+	t.Cleanup(func() { lineEditor = saved })
+}
+
+// TestDisplayLeaderboardGolden replays a board recorded from the fake
+// server and compares the rendered page with testdata/leaderboard.golden.
+// Run with -update after an intended change to the layout.
+func TestDisplayLeaderboardGolden(t *testing.T) {
+	plainOutput(t, 100, 0)
+	savedOptions := globalOptions
+	globalOptions.Replay = filepath.Join("testdata", "fixtures", "leaderboard")
+	t.Cleanup(func() { globalOptions = savedOptions })
+	
+	api := NewSpeedrunAPI()
+	lb, err := api.GetLeaderboard("o1y9j9v6", "7kjrn323", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := api.LoadPlayerNames(lb); err != nil {
+		t.Fatal(err)
+	}
+	
+	var pages int
+	got := captureStdout(t, func() { pages = displayLeaderboard(lb, 1, -1) })
+	if pages != 2 {
+		t.Errorf("displayLeaderboard returned %d pages, want 2", pages)
+	}
+	
+	golden := filepath.Join("testdata", "leaderboard.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal([]byte(got), want) {
+		t.Errorf("output differs from %s:\n--- got\n%s\n--- want\n%s", golden, got, want)
+	}
+}
+
+// TestTablesFitTerminal renders the queue, game, and series tables from the
+// fake server at several widths: columns drop in rank order, text columns
+// narrow, and no table row is wider than the terminal.
+func TestTablesFitTerminal(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	game, err := api.GetGame("sm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue, err := api.GetGameQueue(game.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	categoryNames := loadRunNames(api, game.ID, queue)
+	placements := api.ProjectQueuePlacements(queue)
+	overview, err := api.GetGameOverview(game.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := &Series{ID: "rv7emz49"}
+	series.Names.International = "Super Mario"
+	series.Weblink = "https://www.speedrun.com/series/mario"
+	seriesGames, err := api.GetSeriesGames(series.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := api.GetSeriesOverview(seriesGames)
+	
+	views := map[string]func(){
+		"queue":  func() { displayQueue(game, queue, categoryNames, placements) },
+		"game":   func() { displayGameOverview(overview) },
+		"series": func() { displaySeriesOverview(series, entries) },
+	}
+	
+	tests := []struct {
+		view       string
+		width      int
+		wantHeader []string
+		wantText   []string
+	}{
+		{
+			view:       "queue",
+			width:      160,
+			wantHeader: []string{"#", "Age", "Runner", "Category", "Time", "Would", "Place", "Video"},
+			wantText:   []string{"Tas0       120 Star 1:36:40         #1          https://www.youtube.com/watch?v=r00195"},
+		},
+		{
+			view:       "queue",
+			width:      80,
+			wantHeader: []string{"#", "Age", "Runner", "Category", "Time", "Would", "Place"},
+			wantText:   []string{"cheese     16 Star  14:59           #4\n"},
+		},
+		{
+			view:       "queue",
+			width:      48,
+			wantHeader: []string{"#", "Age", "Runner", "Category", "Time"},
+			wantText:   []string{"froze... 120 Star", "cheese   16 Star"},
+		},
+		{
+			view:       "game",
+			width:      100,
+			wantHeader: []string{"#", "Category", "Type", "Runners", "WR", "Holder", "Date"},
+			wantText:   []string{"120 Star  per-game  40      1:38:50         cheese       2016-12-09"},
+		},
+		{
+			view:       "game",
+			width:      56,
+			wantHeader: []string{"#", "Category", "Type", "WR", "Holder"},
+			wantText:   []string{"0 Star    per-game  6:30            Glitchslayer"},
+		},
+		{
+			view:       "series",
+			width:      120,
+			wantHeader: []string{"#", "Game", "Year", "Category", "Runners", "WR", "Holder", "Date"},
+			wantText:   []string{"Super Mario 64", "Super Mario Odyssey"},
+		},
+		{
+			view:       "series",
+			width:      60,
+			wantHeader: []string{"#", "Game", "Category", "WR", "Holder"},
+			wantText:   []string{"Super Mario Ody... Any%"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s at %d", tt.view, tt.width), func(t *testing.T) {
+			plainOutput(t, tt.width, 50)
+			out := captureStdout(t, views[tt.view])
+			
+			lines := strings.Split(out, "\n")
+			header := -1
+			for i, line := range lines {
+				if strings.HasPrefix(line, "#     ") {
+					header = i
+				}
+			}
+			if header < 0 {
+				t.Fatalf("no table header in:\n%s", out)
+			}
+			if got := strings.Fields(lines[header]); !reflect.DeepEqual(got, tt.wantHeader) {
+				t.Errorf("header = %q, want %q", got, tt.wantHeader)
+			}
+			for _, line := range lines[header:] {
+				if line == "" {
+					break
+				}
+				if w := displayWidth(line); w > tt.width {
+					t.Errorf("line is %d cells wide on a %d-cell terminal: %q", w, tt.width, line)
+				}
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(out, want) {
+					t.Errorf("output is missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,1297 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The fake server serves a subset of the speedrun.com v1 API from the
+// dataset in fakeapi/dataset.json (or a file of the same shape), so the
+// client can be developed and demonstrated without the real site. Faults
+// can be injected to exercise retries and timeouts.
+
+const (
+	DefaultFakeServerAddr = "127.0.0.1:8080"
+	FakeAPIPrefix         = "/api/v1"
+	FakePageSize          = 20
+	FakeMaxPageSize       = 200
+)
+
+//go:embed fakeapi/dataset.json
+var defaultFakeDataset []byte
+
+type fakeObject = map[string]interface{}
+
+// fakeDataset is the server's world. Objects are kept as generic JSON so
+// they are served with exactly the fields in the dataset file.
+type fakeDataset struct {
+	Profile       string       `json:"profile"`
+	Platforms     []fakeObject `json:"platforms"`
+	Regions       []fakeObject `json:"regions"`
+	Users         []fakeObject `json:"users"`
+	Series        []fakeObject `json:"series"`
+	Games         []fakeObject `json:"games"`
+	Categories    []fakeObject `json:"categories"`
+	Levels        []fakeObject `json:"levels"`
+	Variables     []fakeObject `json:"variables"`
+	Runs          []fakeObject `json:"runs"`
+	Notifications []fakeObject `json:"notifications"`
+}
+
+// fakeFaults configures injected failures. An "every" of N affects every
+// Nth request; 0 disables it.
+type fakeFaults struct {
+	Latency        time.Duration
+	Slow           time.Duration
+	SlowEvery      int
+	RateLimitEvery int
+	ErrorEvery     int
+}
+
+type FakeServer struct {
+	mu       sync.Mutex
+	data     fakeDataset
+	faults   fakeFaults
+	requests atomic.Int64
+	nextRun  int
+}
+
+func newFakeServer(dataset []byte, faults fakeFaults) (*FakeServer, error) {
+	server := &FakeServer{faults: faults}
+	if err := json.Unmarshal(dataset, &server.data); err != nil {
+		return nil, fmt.Errorf("parsing dataset: %w", err)
+	}
+	server.nextRun = len(server.data.Runs) + 1
+	return server, nil
+}
+
+func handleFakeServer(args []string) {
+	fs := flag.NewFlagSet("fake-server", flag.ExitOnError)
+	addr := fs.String("addr", DefaultFakeServerAddr, "address to listen on")
+	datasetFile := fs.String("data", "", "dataset JSON file (default: the built-in dataset)")
+	var faults fakeFaults
+	fs.DurationVar(&faults.Latency, "latency", 0, "delay added to every response")
+	fs.DurationVar(&faults.Slow, "slow", 5*time.Second, "delay for responses selected by -slow-every")
+	fs.IntVar(&faults.SlowEvery, "slow-every", 0, "delay every Nth response by -slow")
+	fs.IntVar(&faults.RateLimitEvery, "rate-limit-every", 0, "answer every Nth request with 429 Too Many Requests")
+	fs.IntVar(&faults.ErrorEvery, "error-every", 0, "answer every Nth request with 503 Service Unavailable")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli fake-server [flags]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	dataset := defaultFakeDataset
+	if *datasetFile != "" {
+		var err error
+		if dataset, err = os.ReadFile(*datasetFile); err != nil {
+			fmt.Printf("Error reading dataset: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	
+	server, err := newFakeServer(dataset, faults)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := enableServerLogging(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	fmt.Printf("Fake speedrun.com API listening on http://%s%s\n", *addr, FakeAPIPrefix)
+	fmt.Printf("Point the client at it with: speedrun-cli --api-base http://%s%s\n", *addr, FakeAPIPrefix)
+	if err := http.ListenAndServe(*addr, server.handler()); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// handler routes API paths and applies the configured faults first.
+func (s *FakeServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	routes := map[string]http.HandlerFunc{
+		"GET /games":                                        s.listGames,
+		"GET /games/{game}":                                 s.getGame,
+		"GET /games/{game}/categories":                      s.gameCategories,
+		"GET /games/{game}/levels":                          s.gameLevels,
+		"GET /games/{game}/variables":                       s.gameVariables,
+		"GET /categories/{category}":                        s.getCategory,
+		"GET /categories/{category}/variables":              s.categoryVariables,
+		"GET /levels/{level}":                               s.getLevel,
+		"GET /leaderboards/{game}/category/{category}":      s.leaderboard,
+		"GET /leaderboards/{game}/level/{level}/{category}": s.leaderboard,
+		"GET /runs":                        s.listRuns,
+		"POST /runs":                       s.submitRun,
+		"GET /runs/{run}":                  s.getRun,
+		"PUT /runs/{run}/status":           s.setRunStatus,
+		"GET /users":                       s.listUsers,
+		"GET /users/{user}":                s.getUser,
+		"GET /users/{user}/personal-bests": s.personalBests,
+		"GET /series":                      s.listSeries,
+		"GET /series/{series}":             s.getSeries,
+		"GET /series/{series}/games":       s.seriesGames,
+		"GET /platforms":                   s.listCollection(func(d *fakeDataset) []fakeObject { return d.Platforms }),
+		"GET /platforms/{id}":              s.getFromCollection(func(d *fakeDataset) []fakeObject { return d.Platforms }, "platform"),
+		"GET /regions":                     s.listCollection(func(d *fakeDataset) []fakeObject { return d.Regions }),
+		"GET /regions/{id}":                s.getFromCollection(func(d *fakeDataset) []fakeObject { return d.Regions }, "region"),
+		"GET /profile":                     s.requireKey(s.profile),
+		"GET /notifications":               s.requireKey(s.notifications),
+	}
+	for pattern, handler := range routes {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+FakeAPIPrefix+path, handler)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeError(w, http.StatusNotFound, "The requested resource could not be found.")
+	})
+	
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(s.requests.Add(1))
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		
+		time.Sleep(s.faults.Latency)
+		if every(n, s.faults.SlowEvery) {
+			time.Sleep(s.faults.Slow)
+		}
+		
+		switch {
+		case every(n, s.faults.RateLimitEvery):
+			recorder.Header().Set("Retry-After", "1")
+			writeFakeError(recorder, http.StatusTooManyRequests, "You have exceeded the rate limit.")
+		case every(n, s.faults.ErrorEvery):
+			writeFakeError(recorder, http.StatusServiceUnavailable, "The service is temporarily unavailable.")
+		default:
+			mux.ServeHTTP(recorder, r)
+		}
+		
+		logAccess(r, recorder.status, time.Since(start))
+	})
+}
+
+func every(n, interval int) bool {
+	return interval > 0 && n%interval == 0
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, message string) {
+	writeFakeJSON(w, status, fakeObject{"status": status, "message": message})
+}
+
+func writeFakeData(w http.ResponseWriter, data interface{}) {
+	writeFakeJSON(w, http.StatusOK, fakeObject{"data": data})
+}
+
+func (s *FakeServer) requireKey(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") == "" {
+			writeFakeError(w, http.StatusForbidden, "You must supply an API key with this request.")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Lookups. Callers hold s.mu.
+
+func findByID(objects []fakeObject, id string) fakeObject {
+	for _, object := range objects {
+		if object["id"] == id {
+			return object
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) findGame(key string) fakeObject {
+	for _, game := range s.data.Games {
+		if game["id"] == key || strings.EqualFold(fakeString(game["abbreviation"]), key) {
+			return game
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) findUser(key string) fakeObject {
+	for _, user := range s.data.Users {
+		if user["id"] == key || strings.EqualFold(fakeName(user), key) {
+			return user
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) findSeries(key string) fakeObject {
+	for _, series := range s.data.Series {
+		if series["id"] == key || strings.EqualFold(fakeString(series["abbreviation"]), key) {
+			return series
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) gameCategoryList(gameID string) []fakeObject {
+	return filterObjects(s.data.Categories, func(c fakeObject) bool { return c["game"] == gameID })
+}
+
+func (s *FakeServer) gameLevelList(gameID string) []fakeObject {
+	return filterObjects(s.data.Levels, func(l fakeObject) bool { return l["game"] == gameID })
+}
+
+func (s *FakeServer) gameVariableList(gameID string) []fakeObject {
+	return filterObjects(s.data.Variables, func(v fakeObject) bool { return v["game"] == gameID })
+}
+
+// categoryVariableList returns the variables that apply to a category:
+// its own and the game-wide ones whose scope covers its type.
+func (s *FakeServer) categoryVariableList(category fakeObject) []fakeObject {
+	return filterObjects(s.gameVariableList(fakeString(category["game"])), func(v fakeObject) bool {
+		if v["category"] != nil {
+			return v["category"] == category["id"]
+		}
+		scope, _ := v["scope"].(fakeObject)
+		switch fakeString(scope["type"]) {
+		case "full-game":
+			return category["type"] == "per-game"
+		case "all-levels", "single-level":
+			return category["type"] == "per-level"
+		}
+		return true
+	})
+}
+
+func filterObjects(objects []fakeObject, keep func(fakeObject) bool) []fakeObject {
+	result := []fakeObject{}
+	for _, object := range objects {
+		if keep(object) {
+			result = append(result, object)
+		}
+	}
+	return result
+}
+
+func fakeString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+func fakeName(object fakeObject) string {
+	names, _ := object["names"].(fakeObject)
+	return fakeString(names["international"])
+}
+
+func fakeStrings(value interface{}) []string {
+	var result []string
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		result = append(result, fakeString(item))
+	}
+	return result
+}
+
+func copyObject(object fakeObject) fakeObject {
+	result := make(fakeObject, len(object))
+	for k, v := range object {
+		result[k] = v
+	}
+	return result
+}
+
+func parseEmbeds(r *http.Request) map[string]bool {
+	embeds := make(map[string]bool)
+	for _, embed := range strings.Split(r.URL.Query().Get("embed"), ",") {
+		if embed != "" {
+			embeds[embed] = true
+		}
+	}
+	return embeds
+}
+
+// paginate serves one page of a list with the API's pagination block and
+// next/prev links.
+func paginate(w http.ResponseWriter, r *http.Request, items []fakeObject) {
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	max, err := strconv.Atoi(query.Get("max"))
+	if err != nil || max <= 0 {
+		max = FakePageSize
+	}
+	if max > FakeMaxPageSize {
+		max = FakeMaxPageSize
+	}
+	if offset < 0 || offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + max
+	if end > len(items) {
+		end = len(items)
+	}
+	page := items[offset:end]
+	
+	link := func(rel string, at int) fakeObject {
+		q := r.URL.Query()
+		q.Set("offset", strconv.Itoa(at))
+		q.Set("max", strconv.Itoa(max))
+		return fakeObject{"rel": rel, "uri": fmt.Sprintf("http://%s%s?%s", r.Host, r.URL.Path, q.Encode())}
+	}
+	links := []fakeObject{}
+	if offset > 0 {
+		prev := offset - max
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, link("prev", prev))
+	}
+	if end < len(items) {
+		links = append(links, link("next", end))
+	}
+	
+	writeFakeJSON(w, http.StatusOK, fakeObject{
+		"data": page,
+		"pagination": fakeObject{
+			"offset": offset,
+			"max":    max,
+			"size":   len(page),
+			"links":  links,
+		},
+	})
+}
+
+// Rendering with embeds. Callers hold s.mu.
+
+func namedResources(names []string) []fakeObject {
+	result := []fakeObject{}
+	for _, name := range names {
+		result = append(result, fakeObject{"id": strings.ToLower(strings.ReplaceAll(name, " ", "-")), "name": name})
+	}
+	return result
+}
+
+func (s *FakeServer) renderGame(game fakeObject, embeds map[string]bool) fakeObject {
+	result := copyObject(game)
+	gameID := fakeString(game["id"])
+	
+	if embeds["platforms"] {
+		var platforms []fakeObject
+		for _, id := range fakeStrings(game["platforms"]) {
+			if platform := findByID(s.data.Platforms, id); platform != nil {
+				platforms = append(platforms, platform)
+			}
+		}
+		result["platforms"] = fakeObject{"data": platforms}
+	}
+	if embeds["regions"] {
+		var regions []fakeObject
+		for _, id := range fakeStrings(game["regions"]) {
+			if region := findByID(s.data.Regions, id); region != nil {
+				regions = append(regions, region)
+			}
+		}
+		result["regions"] = fakeObject{"data": regions}
+	}
+	for _, field := range []string{"genres", "engines", "developers", "publishers"} {
+		resources := namedResources(fakeStrings(game[field]))
+		if embeds[field] {
+			result[field] = fakeObject{"data": resources}
+			continue
+		}
+		ids := []string{}
+		for _, resource := range resources {
+			ids = append(ids, fakeString(resource["id"]))
+		}
+		result[field] = ids
+	}
+	
+	moderatorIDs := fakeStrings(game["moderators"])
+	if embeds["moderators"] {
+		var moderators []fakeObject
+		for _, id := range moderatorIDs {
+			if user := findByID(s.data.Users, id); user != nil {
+				moderators = append(moderators, user)
+			}
+		}
+		result["moderators"] = fakeObject{"data": moderators}
+	} else {
+		roles := fakeObject{}
+		for _, id := range moderatorIDs {
+			roles[id] = "moderator"
+		}
+		result["moderators"] = roles
+	}
+	
+	if embeds["categories"] {
+		result["categories"] = fakeObject{"data": s.gameCategoryList(gameID)}
+	}
+	if embeds["levels"] {
+		result["levels"] = fakeObject{"data": s.gameLevelList(gameID)}
+	}
+	if embeds["variables"] {
+		result["variables"] = fakeObject{"data": s.gameVariableList(gameID)}
+	}
+	return result
+}
+
+func (s *FakeServer) renderRun(run fakeObject, embeds map[string]bool) fakeObject {
+	result := copyObject(run)
+	if embeds["game"] {
+		if game := findByID(s.data.Games, fakeString(run["game"])); game != nil {
+			result["game"] = fakeObject{"data": s.renderGame(game, nil)}
+		}
+	}
+	if embeds["category"] {
+		if category := findByID(s.data.Categories, fakeString(run["category"])); category != nil {
+			result["category"] = fakeObject{"data": category}
+		}
+	}
+	if embeds["players"] {
+		result["players"] = fakeObject{"data": s.runPlayers(run)}
+	}
+	return result
+}
+
+func (s *FakeServer) runPlayers(run fakeObject) []fakeObject {
+	var players []fakeObject
+	list, _ := run["players"].([]interface{})
+	for _, item := range list {
+		player, _ := item.(fakeObject)
+		if user := findByID(s.data.Users, fakeString(player["id"])); user != nil {
+			players = append(players, user)
+		} else {
+			players = append(players, fakeObject{"rel": "guest", "name": player["name"]})
+		}
+	}
+	return players
+}
+
+// Handlers
+
+func (s *FakeServer) listGames(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	query := r.URL.Query()
+	name := strings.ToLower(query.Get("name"))
+	abbreviation := query.Get("abbreviation")
+	embeds := parseEmbeds(r)
+	
+	var games []fakeObject
+	for _, game := range s.data.Games {
+		if name != "" && !strings.Contains(strings.ToLower(fakeName(game)), name) && strings.ToLower(fakeString(game["abbreviation"])) != name {
+			continue
+		}
+		if abbreviation != "" && !strings.EqualFold(fakeString(game["abbreviation"]), abbreviation) {
+			continue
+		}
+		games = append(games, s.renderGame(game, embeds))
+	}
+	paginate(w, r, games)
+}
+
+func (s *FakeServer) getGame(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	game := s.findGame(r.PathValue("game"))
+	if game == nil {
+		writeFakeError(w, http.StatusNotFound, fmt.Sprintf("Game %q could not be found.", r.PathValue("game")))
+		return
+	}
+	writeFakeData(w, s.renderGame(game, parseEmbeds(r)))
+}
+
+func (s *FakeServer) gameCategories(w http.ResponseWriter, r *http.Request) {
+	s.gameList(w, r, s.gameCategoryList)
+}
+
+func (s *FakeServer) gameLevels(w http.ResponseWriter, r *http.Request) {
+	s.gameList(w, r, s.gameLevelList)
+}
+
+func (s *FakeServer) gameVariables(w http.ResponseWriter, r *http.Request) {
+	s.gameList(w, r, s.gameVariableList)
+}
+
+func (s *FakeServer) gameList(w http.ResponseWriter, r *http.Request, list func(gameID string) []fakeObject) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	game := s.findGame(r.PathValue("game"))
+	if game == nil {
+		writeFakeError(w, http.StatusNotFound, fmt.Sprintf("Game %q could not be found.", r.PathValue("game")))
+		return
+	}
+	writeFakeData(w, list(fakeString(game["id"])))
+}
+
+func (s *FakeServer) getCategory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	category := findByID(s.data.Categories, r.PathValue("category"))
+	if category == nil {
+		writeFakeError(w, http.StatusNotFound, "Category could not be found.")
+		return
+	}
+	writeFakeData(w, category)
+}
+
+func (s *FakeServer) categoryVariables(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	category := findByID(s.data.Categories, r.PathValue("category"))
+	if category == nil {
+		writeFakeError(w, http.StatusNotFound, "Category could not be found.")
+		return
+	}
+	writeFakeData(w, s.categoryVariableList(category))
+}
+
+func (s *FakeServer) getLevel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	level := findByID(s.data.Levels, r.PathValue("level"))
+	if level == nil {
+		writeFakeError(w, http.StatusNotFound, "Level could not be found.")
+		return
+	}
+	writeFakeData(w, level)
+}
+
+func (s *FakeServer) listCollection(collection func(*fakeDataset) []fakeObject) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		paginate(w, r, collection(&s.data))
+	}
+}
+
+func (s *FakeServer) getFromCollection(collection func(*fakeDataset) []fakeObject, kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		
+		object := findByID(collection(&s.data), r.PathValue("id"))
+		if object == nil {
+			writeFakeError(w, http.StatusNotFound, fmt.Sprintf("The %s could not be found.", kind))
+			return
+		}
+		writeFakeData(w, object)
+	}
+}
+
+// leaderboard ranks verified runs like the real API: filters from the
+// query string, one run per player set (the fastest), ties sharing a
+// place, and optional embeds.
+func (s *FakeServer) leaderboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	game := s.findGame(r.PathValue("game"))
+	if game == nil {
+		writeFakeError(w, http.StatusNotFound, fmt.Sprintf("Game %q could not be found.", r.PathValue("game")))
+		return
+	}
+	gameID := fakeString(game["id"])
+	
+	category := findByID(s.data.Categories, r.PathValue("category"))
+	if category == nil || category["game"] != gameID {
+		writeFakeError(w, http.StatusNotFound, "Category could not be found.")
+		return
+	}
+	
+	var level fakeObject
+	if levelID := r.PathValue("level"); levelID != "" {
+		if level = findByID(s.data.Levels, levelID); level == nil || level["game"] != gameID {
+			writeFakeError(w, http.StatusNotFound, "Level could not be found.")
+			return
+		}
+		if category["type"] != "per-level" {
+			writeFakeError(w, http.StatusBadRequest, "This category is not a per-level category.")
+			return
+		}
+	} else if category["type"] != "per-game" {
+		writeFakeError(w, http.StatusBadRequest, "This category is a per-level category; use the level leaderboard.")
+		return
+	}
+	
+	query := r.URL.Query()
+	timing := query.Get("timing")
+	if timing == "" {
+		timing = defaultTiming(game)
+	}
+	values := fakeObject{}
+	for key := range query {
+		if variable, ok := strings.CutPrefix(key, "var-"); ok {
+			values[variable] = query.Get(key)
+		}
+	}
+	
+	ranked := s.rankRuns(gameID, category, level, timing, func(run fakeObject) bool {
+		system, _ := run["system"].(fakeObject)
+		return fakeRunMatches(run, system, query, values)
+	})
+	
+	top, _ := strconv.Atoi(query.Get("top"))
+	entries := []fakeObject{}
+	var players, platforms, regions []fakeObject
+	seenPlatforms := make(map[string]bool)
+	seenRegions := make(map[string]bool)
+	for _, entry := range ranked {
+		if top > 0 && entry["place"].(int) > top {
+			break
+		}
+		entries = append(entries, entry)
+		run := entry["run"].(fakeObject)
+		
+		players = append(players, s.runPlayers(run)...)
+		system, _ := run["system"].(fakeObject)
+		if id := fakeString(system["platform"]); id != "" && !seenPlatforms[id] {
+			seenPlatforms[id] = true
+			if platform := findByID(s.data.Platforms, id); platform != nil {
+				platforms = append(platforms, platform)
+			}
+		}
+		if id := fakeString(system["region"]); id != "" && !seenRegions[id] {
+			seenRegions[id] = true
+			if region := findByID(s.data.Regions, id); region != nil {
+				regions = append(regions, region)
+			}
+		}
+	}
+	
+	weblink := fakeString(category["weblink"])
+	if level != nil {
+		weblink = fakeString(level["weblink"])
+	}
+	board := fakeObject{
+		"weblink":    weblink,
+		"game":       gameID,
+		"category":   category["id"],
+		"level":      nil,
+		"platform":   emptyToNil(query.Get("platform")),
+		"region":     emptyToNil(query.Get("region")),
+		"emulators":  emptyToNil(query.Get("emulators")),
+		"video-only": query.Get("video-only") == "true",
+		"timing":     timing,
+		"values":     values,
+		"runs":       entries,
+	}
+	if level != nil {
+		board["level"] = level["id"]
+	}
+	
+	embeds := parseEmbeds(r)
+	if embeds["game"] {
+		board["game"] = fakeObject{"data": s.renderGame(game, nil)}
+	}
+	if embeds["category"] {
+		board["category"] = fakeObject{"data": category}
+	}
+	if embeds["level"] && level != nil {
+		board["level"] = fakeObject{"data": level}
+	}
+	if embeds["players"] {
+		board["players"] = fakeObject{"data": players}
+	}
+	if embeds["platforms"] {
+		board["platforms"] = fakeObject{"data": platforms}
+	}
+	if embeds["regions"] {
+		board["regions"] = fakeObject{"data": regions}
+	}
+	if embeds["variables"] {
+		board["variables"] = fakeObject{"data": s.categoryVariableList(category)}
+	}
+	writeFakeData(w, board)
+}
+
+// rankRuns returns the {place, run} entries of a board: the verified runs
+// that keep accepts, one per player set (the fastest), ordered by time and
+// then date, with tied times sharing a place.
+func (s *FakeServer) rankRuns(gameID string, category, level fakeObject, timing string, keep func(fakeObject) bool) []fakeObject {
+	timeOf := func(run fakeObject) float64 {
+		times, _ := run["times"].(fakeObject)
+		if t := fakeString(times[timing]); t != "" {
+			return ptSeconds(t)
+		}
+		return ptSeconds(fakeString(times["primary"]))
+	}
+	
+	best := make(map[string]fakeObject)
+	for _, run := range s.data.Runs {
+		status, _ := run["status"].(fakeObject)
+		if run["game"] != gameID || run["category"] != category["id"] || status["status"] != "verified" {
+			continue
+		}
+		// Category boards leave out level runs, as on the real site
+		if fakeString(run["level"]) != fakeString(level["id"]) {
+			continue
+		}
+		if !keep(run) {
+			continue
+		}
+		
+		var players []string
+		for _, player := range s.runPlayers(run) {
+			players = append(players, fakeString(player["id"])+fakeString(player["name"]))
+		}
+		key := strings.Join(players, ",")
+		if current, exists := best[key]; !exists || timeOf(run) < timeOf(current) {
+			best[key] = run
+		}
+	}
+	
+	ranked := make([]fakeObject, 0, len(best))
+	for _, run := range best {
+		ranked = append(ranked, run)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if timeOf(ranked[i]) != timeOf(ranked[j]) {
+			return timeOf(ranked[i]) < timeOf(ranked[j])
+		}
+		return fakeString(ranked[i]["date"]) < fakeString(ranked[j]["date"])
+	})
+	
+	entries := make([]fakeObject, len(ranked))
+	for i, run := range ranked {
+		place := i + 1
+		if i > 0 && timeOf(run) == timeOf(ranked[i-1]) {
+			place = entries[i-1]["place"].(int)
+		}
+		entries[i] = fakeObject{"place": place, "run": run}
+	}
+	return entries
+}
+
+// defaultTiming is the timing method a game's boards are ranked by.
+func defaultTiming(game fakeObject) string {
+	ruleset, _ := game["ruleset"].(fakeObject)
+	return fakeString(ruleset["default-time"])
+}
+
+// fakeRunMatches applies the leaderboard query filters to a run.
+func fakeRunMatches(run, system fakeObject, query url.Values, values fakeObject) bool {
+	if platform := query.Get("platform"); platform != "" && system["platform"] != platform {
+		return false
+	}
+	if region := query.Get("region"); region != "" && system["region"] != region {
+		return false
+	}
+	if emulators := query.Get("emulators"); emulators != "" && system["emulated"] != (emulators == "true") {
+		return false
+	}
+	if query.Get("video-only") == "true" && run["videos"] == nil {
+		return false
+	}
+	if date := query.Get("date"); date != "" && fakeString(run["date"]) > date {
+		return false
+	}
+	
+	runValues, _ := run["values"].(fakeObject)
+	for variable, value := range values {
+		if runValues[variable] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func emptyToNil(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func (s *FakeServer) listRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	query := r.URL.Query()
+	filters := map[string]func(fakeObject) string{
+		"game":     func(run fakeObject) string { return fakeString(run["game"]) },
+		"category": func(run fakeObject) string { return fakeString(run["category"]) },
+		"level":    func(run fakeObject) string { return fakeString(run["level"]) },
+		"status": func(run fakeObject) string {
+			status, _ := run["status"].(fakeObject)
+			return fakeString(status["status"])
+		},
+		"platform": func(run fakeObject) string {
+			system, _ := run["system"].(fakeObject)
+			return fakeString(system["platform"])
+		},
+	}
+	
+	user := query.Get("user")
+	if user != "" {
+		if found := s.findUser(user); found != nil {
+			user = fakeString(found["id"])
+		}
+	}
+	if game := query.Get("game"); game != "" {
+		if found := s.findGame(game); found != nil {
+			query.Set("game", fakeString(found["id"]))
+		}
+	}
+	
+	var runs []fakeObject
+	for _, run := range s.data.Runs {
+		matches := true
+		for param, field := range filters {
+			if want := query.Get(param); want != "" && field(run) != want {
+				matches = false
+			}
+		}
+		if user != "" && !s.hasPlayer(run, user) {
+			matches = false
+		}
+		if matches {
+			runs = append(runs, run)
+		}
+	}
+	
+	orderBy := query.Get("orderby")
+	if orderBy == "" {
+		orderBy = "game"
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		a, b := fakeSortKey(runs[i], orderBy), fakeSortKey(runs[j], orderBy)
+		if query.Get("direction") == "desc" {
+			return a > b
+		}
+		return a < b
+	})
+	
+	embeds := parseEmbeds(r)
+	rendered := make([]fakeObject, len(runs))
+	for i, run := range runs {
+		rendered[i] = s.renderRun(run, embeds)
+	}
+	paginate(w, r, rendered)
+}
+
+func fakeSortKey(run fakeObject, orderBy string) string {
+	switch orderBy {
+	case "date", "submitted", "category", "level", "game":
+		return fakeString(run[orderBy])
+	case "verify-date":
+		status, _ := run["status"].(fakeObject)
+		return fakeString(status["verify-date"])
+	case "emulated":
+		system, _ := run["system"].(fakeObject)
+		return fmt.Sprint(system["emulated"])
+	}
+	return fakeString(run["id"])
+}
+
+func (s *FakeServer) getRun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	run := findByID(s.data.Runs, r.PathValue("run"))
+	if run == nil {
+		writeFakeError(w, http.StatusNotFound, "Run could not be found.")
+		return
+	}
+	writeFakeData(w, s.renderRun(run, parseEmbeds(r)))
+}
+
+// setRunStatus verifies or rejects a run, as a moderator would.
+func (s *FakeServer) setRunStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-API-Key") == "" {
+		writeFakeError(w, http.StatusForbidden, "You must supply an API key with this request.")
+		return
+	}
+	
+	var request struct {
+		Status struct {
+			Status string `json:"status"`
+			Reason string `json:"reason"`
+		} `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+		return
+	}
+	switch {
+	case request.Status.Status != "verified" && request.Status.Status != "rejected":
+		writeFakeError(w, http.StatusBadRequest, "status must be \"verified\" or \"rejected\".")
+		return
+	case request.Status.Status == "rejected" && request.Status.Reason == "":
+		writeFakeError(w, http.StatusBadRequest, "A reason is required when rejecting a run.")
+		return
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	run := findByID(s.data.Runs, r.PathValue("run"))
+	if run == nil {
+		writeFakeError(w, http.StatusNotFound, "Run could not be found.")
+		return
+	}
+	
+	status := fakeObject{"status": request.Status.Status, "examiner": s.data.Profile}
+	if request.Status.Status == "verified" {
+		status["verify-date"] = time.Now().UTC().Format(time.RFC3339)
+	} else {
+		status["reason"] = request.Status.Reason
+	}
+	run["status"] = status
+	writeFakeData(w, run)
+}
+
+// submitRun accepts a run submission, checking the fields the real API
+// requires, and adds it to the verification queue.
+func (s *FakeServer) submitRun(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-API-Key") == "" {
+		writeFakeError(w, http.StatusForbidden, "You must supply an API key with this request.")
+		return
+	}
+	
+	var request struct {
+		Run RunSubmission `json:"run"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+		return
+	}
+	submission := request.Run
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	category := findByID(s.data.Categories, submission.Category)
+	var problems []string
+	if category == nil {
+		problems = append(problems, "category: unknown category")
+	}
+	if submission.Platform != "" && findByID(s.data.Platforms, submission.Platform) == nil {
+		problems = append(problems, "platform: unknown platform")
+	}
+	if submission.Times.Realtime == 0 && submission.Times.RealtimeNoLoads == 0 && submission.Times.Ingame == 0 {
+		problems = append(problems, "times: at least one time is required")
+	}
+	if len(problems) > 0 {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{
+			"status":  http.StatusBadRequest,
+			"message": "The submitted run is invalid.",
+			"errors":  problems,
+		})
+		return
+	}
+	
+	game := findByID(s.data.Games, fakeString(category["game"]))
+	id := fmt.Sprintf("r%05d", s.nextRun)
+	s.nextRun++
+	
+	times := fakeObject{}
+	primary := 0.0
+	for _, t := range []struct {
+		key     string
+		seconds float64
+	}{{"ingame", submission.Times.Ingame}, {"realtime_noloads", submission.Times.RealtimeNoLoads}, {"realtime", submission.Times.Realtime}} {
+		times[t.key+"_t"] = t.seconds
+		times[t.key] = nil
+		if t.seconds > 0 {
+			times[t.key] = secondsToPT(t.seconds)
+			primary = t.seconds
+		}
+	}
+	times["primary"] = secondsToPT(primary)
+	times["primary_t"] = primary
+	
+	values := fakeObject{}
+	for variable, value := range submission.Variables {
+		values[variable] = value.Value
+	}
+	var videos interface{}
+	if submission.Video != "" {
+		videos = fakeObject{"links": []fakeObject{{"uri": submission.Video}}}
+	}
+	var level interface{}
+	if submission.Level != "" {
+		level = submission.Level
+	}
+	
+	run := fakeObject{
+		"id":        id,
+		"weblink":   fmt.Sprintf("%s/run/%s", fakeString(game["weblink"]), id),
+		"game":      game["id"],
+		"level":     level,
+		"category":  submission.Category,
+		"videos":    videos,
+		"comment":   submission.Comment,
+		"status":    fakeObject{"status": "new"},
+		"players":   []interface{}{fakeObject{"rel": "user", "id": s.data.Profile}},
+		"date":      submission.Date,
+		"submitted": time.Now().UTC().Format(time.RFC3339),
+		"times":     times,
+		"system":    fakeObject{"platform": submission.Platform, "emulated": submission.Emulated, "region": emptyToNil(submission.Region)},
+		"values":    values,
+	}
+	s.data.Runs = append(s.data.Runs, run)
+	writeFakeJSON(w, http.StatusCreated, fakeObject{"data": run})
+}
+
+// secondsToPT formats a time as an ISO 8601 duration to the millisecond,
+// like speedrun.com does, so 5025.678 is "PT1H23M45.678S".
+func secondsToPT(seconds float64) string {
+	ms := int64(math.Round(seconds * 1000))
+	result := "PT"
+	if h := ms / 3600000; h > 0 {
+		result += fmt.Sprintf("%dH", h)
+	}
+	if m := ms / 60000 % 60; m > 0 {
+		result += fmt.Sprintf("%dM", m)
+	}
+	if s := ms % 60000; s > 0 || result == "PT" {
+		result += strconv.FormatFloat(float64(s)/1000, 'f', -1, 64) + "S"
+	}
+	return result
+}
+
+func (s *FakeServer) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	query := r.URL.Query()
+	lookup := query.Get("lookup")
+	name := strings.ToLower(query.Get("name"))
+	
+	var users []fakeObject
+	for _, user := range s.data.Users {
+		if lookup != "" && !strings.EqualFold(fakeName(user), lookup) {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(fakeName(user)), name) {
+			continue
+		}
+		users = append(users, user)
+	}
+	paginate(w, r, users)
+}
+
+func (s *FakeServer) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	user := s.findUser(r.PathValue("user"))
+	if user == nil {
+		writeFakeError(w, http.StatusNotFound, "User could not be found.")
+		return
+	}
+	writeFakeData(w, user)
+}
+
+// personalBests lists the user's place on every board they have a
+// verified run on. Boards are split by subcategory variable values.
+func (s *FakeServer) personalBests(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	user := s.findUser(r.PathValue("user"))
+	if user == nil {
+		writeFakeError(w, http.StatusNotFound, "User could not be found.")
+		return
+	}
+	userID := fakeString(user["id"])
+	
+	query := r.URL.Query()
+	if game := query.Get("game"); game != "" {
+		if found := s.findGame(game); found != nil {
+			query.Set("game", fakeString(found["id"]))
+		}
+	}
+	top, _ := strconv.Atoi(query.Get("top"))
+	embeds := parseEmbeds(r)
+	
+	pbs := []fakeObject{}
+	seen := make(map[string]bool)
+	for _, run := range s.data.Runs {
+		status, _ := run["status"].(fakeObject)
+		if status["status"] != "verified" || !s.hasPlayer(run, userID) {
+			continue
+		}
+		if game := query.Get("game"); game != "" && run["game"] != game {
+			continue
+		}
+		
+		game := findByID(s.data.Games, fakeString(run["game"]))
+		category := findByID(s.data.Categories, fakeString(run["category"]))
+		if game == nil || category == nil {
+			continue
+		}
+		var level fakeObject
+		if levelID := fakeString(run["level"]); levelID != "" {
+			level = findByID(s.data.Levels, levelID)
+		}
+		
+		subcategory := fakeObject{}
+		runValues, _ := run["values"].(fakeObject)
+		for _, variable := range s.categoryVariableList(category) {
+			if variable["is-subcategory"] == true {
+				subcategory[fakeString(variable["id"])] = runValues[fakeString(variable["id"])]
+			}
+		}
+		key := fmt.Sprint(run["game"], run["category"], run["level"], subcategory)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		
+		board := s.rankRuns(fakeString(game["id"]), category, level, defaultTiming(game), func(other fakeObject) bool {
+			otherValues, _ := other["values"].(fakeObject)
+			for variable, value := range subcategory {
+				if otherValues[variable] != value {
+					return false
+				}
+			}
+			return true
+		})
+		for _, entry := range board {
+			if s.hasPlayer(entry["run"].(fakeObject), userID) {
+				if top == 0 || entry["place"].(int) <= top {
+					pbs = append(pbs, s.renderPersonalBest(entry, game, category, level, embeds))
+				}
+				break
+			}
+		}
+	}
+	writeFakeData(w, pbs)
+}
+
+// renderPersonalBest embeds resources next to the run rather than inside
+// it, as the real personal-bests endpoint does. Missing ones embed as an
+// empty list.
+func (s *FakeServer) renderPersonalBest(entry, game, category, level fakeObject, embeds map[string]bool) fakeObject {
+	run := entry["run"].(fakeObject)
+	result := fakeObject{"place": entry["place"], "run": run}
+	embedded := func(object fakeObject) fakeObject {
+		if object == nil {
+			return fakeObject{"data": []interface{}{}}
+		}
+		return fakeObject{"data": object}
+	}
+	
+	system, _ := run["system"].(fakeObject)
+	if embeds["game"] {
+		result["game"] = embedded(s.renderGame(game, nil))
+	}
+	if embeds["category"] {
+		result["category"] = embedded(category)
+	}
+	if embeds["level"] {
+		result["level"] = embedded(level)
+	}
+	if embeds["platform"] {
+		result["platform"] = embedded(findByID(s.data.Platforms, fakeString(system["platform"])))
+	}
+	if embeds["region"] {
+		result["region"] = embedded(findByID(s.data.Regions, fakeString(system["region"])))
+	}
+	if embeds["players"] {
+		result["players"] = fakeObject{"data": s.runPlayers(run)}
+	}
+	return result
+}
+
+func (s *FakeServer) hasPlayer(run fakeObject, userID string) bool {
+	for _, player := range s.runPlayers(run) {
+		if player["id"] == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FakeServer) listSeries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	name := strings.ToLower(r.URL.Query().Get("name"))
+	series := filterObjects(s.data.Series, func(series fakeObject) bool {
+		return name == "" || strings.Contains(strings.ToLower(fakeName(series)), name)
+	})
+	paginate(w, r, series)
+}
+
+func (s *FakeServer) getSeries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	series := s.findSeries(r.PathValue("series"))
+	if series == nil {
+		writeFakeError(w, http.StatusNotFound, "Series could not be found.")
+		return
+	}
+	writeFakeData(w, series)
+}
+
+func (s *FakeServer) seriesGames(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	series := s.findSeries(r.PathValue("series"))
+	if series == nil {
+		writeFakeError(w, http.StatusNotFound, "Series could not be found.")
+		return
+	}
+	
+	embeds := parseEmbeds(r)
+	var games []fakeObject
+	for _, id := range fakeStrings(series["games"]) {
+		if game := s.findGame(id); game != nil {
+			games = append(games, s.renderGame(game, embeds))
+		}
+	}
+	if r.URL.Query().Get("orderby") == "released" {
+		sort.SliceStable(games, func(i, j int) bool {
+			return fakeString(games[i]["release-date"]) < fakeString(games[j]["release-date"])
+		})
+	}
+	paginate(w, r, games)
+}
+
+func (s *FakeServer) profile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeFakeData(w, findByID(s.data.Users, s.data.Profile))
+}
+
+func (s *FakeServer) notifications(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paginate(w, r, s.data.Notifications)
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startFakeAPI serves the bundled dataset with the given faults and returns
+// a client pointed at it. Retries do not back off, so fault tests run fast.
+func startFakeAPI(t *testing.T, faults fakeFaults) (*SpeedrunAPI, *FakeServer) {
+	t.Helper()
+	server, err := newFakeServer(defaultFakeDataset, faults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server.handler())
+	t.Cleanup(httpServer.Close)
+	
+	savedTheme := theme
+	theme.Interactive = false
+	t.Cleanup(func() { theme = savedTheme })
+	
+	api := &SpeedrunAPI{
+		client:     httpServer.Client(),
+		baseURL:    httpServer.URL + FakeAPIPrefix,
+		timeout:    5 * time.Second,
+		maxRetries: 2,
+		userCache:  make(map[string]*User),
+	}
+	
+	// Display helpers that look runners up through their own client, like
+	// getPlayerDisplayName, reach the fake server too, never the network
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	savedSettings := settings
+	settings, err = resolveSettings(Config{Values: map[string]string{
+		"api_base":     api.baseURL,
+		"backoff_base": "0",
+		"rate_limit":   "0",
+	}}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { settings = savedSettings })
+	return api, server
+}
+
+func TestFakeServerLeaderboardEmbedsPlayers(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	
+	tests := []struct {
+		name     string
+		endpoint string
+	}{
+		{name: "whole board", endpoint: "/leaderboards/sm64/category/wkpoo02r?embed=players"},
+		{name: "JP subcategory", endpoint: "/leaderboards/o1y9wo6q/category/wkpoo02r?embed=players&var-e8m7em86=9qj7z0oq"},
+		{name: "level board", endpoint: "/leaderboards/o1y9wo6q/level/5wkjv5d3/xk9gx4gd?embed=players"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := api.makeRequest(tt.endpoint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp struct {
+				Data struct {
+					Runs []struct {
+						Place int `json:"place"`
+						Run   Run `json:"run"`
+					} `json:"runs"`
+					Players struct {
+						Data []User `json:"data"`
+					} `json:"players"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Data.Runs) == 0 {
+				t.Fatal("board has no runs")
+			}
+			
+			embedded := make(map[string]bool)
+			for _, user := range resp.Data.Players.Data {
+				if user.Names.International == "" {
+					t.Errorf("embedded user %s has no name", user.ID)
+				}
+				embedded[user.ID] = true
+			}
+			
+			seen := make(map[string]bool)
+			for i, entry := range resp.Data.Runs {
+				player := entry.Run.Players[0]
+				if player.Rel == "user" && !embedded[player.ID] {
+					t.Errorf("runner %s of run %s is not embedded", player.ID, entry.Run.ID)
+				}
+				if seen[player.ID+player.Name] {
+					t.Errorf("runner %s has more than one run on the board", player.ID+player.Name)
+				}
+				seen[player.ID+player.Name] = true
+				
+				if i > 0 {
+					prev := resp.Data.Runs[i-1]
+					if entry.Place < prev.Place || (entry.Place == prev.Place && entry.Run.Times.Primary != prev.Run.Times.Primary) {
+						t.Errorf("place %d (%s) after place %d (%s)", entry.Place, entry.Run.Times.Primary, prev.Place, prev.Run.Times.Primary)
+					}
+				}
+				if strings.Contains(tt.endpoint, "var-e8m7em86=9qj7z0oq") && entry.Run.Values["e8m7em86"] != "9qj7z0oq" {
+					t.Errorf("run %s is not a JP run", entry.Run.ID)
+				}
+			}
+			if len(embedded) != len(seen) {
+				t.Errorf("%d players embedded for %d runners", len(embedded), len(seen))
+			}
+		})
+	}
+}
+
+func TestFakeServerRunsPagination(t *testing.T) {
+	api, server := startFakeAPI(t, fakeFaults{})
+	
+	want := 0
+	for _, run := range server.data.Runs {
+		if run["game"] == "o1y9wo6q" {
+			want++
+		}
+	}
+	
+	seen := make(map[string]bool)
+	pages := 0
+	endpoint := "/runs?game=sm64&max=30"
+	for endpoint != "" {
+		body, err := api.makeRequest(endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp APIResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		var runs []Run
+		if err := json.Unmarshal(resp.Data, &runs); err != nil {
+			t.Fatal(err)
+		}
+		for _, run := range runs {
+			if seen[run.ID] {
+				t.Errorf("run %s served twice", run.ID)
+			}
+			seen[run.ID] = true
+		}
+		if resp.Pagination.Offset != pages*30 || resp.Pagination.Size != len(runs) {
+			t.Errorf("page %d: pagination %+v for %d runs", pages+1, resp.Pagination, len(runs))
+		}
+		
+		hasPrev := false
+		endpoint = ""
+		for _, link := range resp.Pagination.Links {
+			switch link.Rel {
+			case "prev":
+				hasPrev = true
+			case "next":
+				// Links are absolute, like the real API's
+				next, err := url.Parse(link.URI)
+				if err != nil || !strings.HasPrefix(link.URI, api.baseURL) {
+					t.Fatalf("bad next link %q", link.URI)
+				}
+				endpoint = strings.TrimPrefix(next.Path, FakeAPIPrefix) + "?" + next.RawQuery
+			}
+		}
+		if hasPrev != (pages > 0) {
+			t.Errorf("page %d: prev link present = %v", pages+1, hasPrev)
+		}
+		pages++
+	}
+	
+	if len(seen) != want {
+		t.Errorf("paged through %d runs, want %d", len(seen), want)
+	}
+	if wantPages := (want + 29) / 30; pages != wantPages {
+		t.Errorf("got %d pages, want %d", pages, wantPages)
+	}
+}
+
+func TestMakeRequestWithRetryAgainstFaults(t *testing.T) {
+	submission := []byte(`{"run": {"category": "wkpoo02r", "platform": "w89rwelk", "date": "2024-01-01", "times": {"realtime": 6000}}}`)
+	
+	tests := []struct {
+		name         string
+		faults       fakeFaults
+		warmUp       bool // send one request first, so the next one hits the fault
+		method       string
+		wantStatus   int // 0 for success
+		wantRequests int64
+	}{
+		{name: "429 is retried", faults: fakeFaults{RateLimitEvery: 2}, warmUp: true, method: "GET", wantRequests: 3},
+		{name: "503 is retried", faults: fakeFaults{ErrorEvery: 2}, warmUp: true, method: "GET", wantRequests: 3},
+		{name: "retries run out", faults: fakeFaults{ErrorEvery: 1}, method: "GET", wantStatus: http.StatusServiceUnavailable, wantRequests: 4},
+		{name: "rate limits run out", faults: fakeFaults{RateLimitEvery: 1}, method: "GET", wantStatus: http.StatusTooManyRequests, wantRequests: 4},
+		{name: "POST is not retried on 503", faults: fakeFaults{ErrorEvery: 2}, warmUp: true, method: "POST", wantStatus: http.StatusServiceUnavailable, wantRequests: 2},
+		{name: "POST is retried on 429", faults: fakeFaults{RateLimitEvery: 2}, warmUp: true, method: "POST", wantRequests: 3},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, server := startFakeAPI(t, tt.faults)
+			api.apiKey = "test-key"
+			queued := len(server.data.Runs)
+			
+			if tt.warmUp {
+				if _, err := api.makeRequestWithRetry("GET", "/games/sm64", nil, 0, "off"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			
+			var err error
+			if tt.method == "POST" {
+				_, err = api.makeRequestWithRetry("POST", "/runs", submission, 3, "off")
+			} else {
+				_, err = api.makeRequestWithRetry("GET", "/games/sm64", nil, 3, "off")
+			}
+			
+			var apiErr *APIError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Errorf("request failed: %v", err)
+			case tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus):
+				t.Errorf("error = %v, want status %d", err, tt.wantStatus)
+			}
+			if got := server.requests.Load(); got != tt.wantRequests {
+				t.Errorf("server saw %d requests, want %d", got, tt.wantRequests)
+			}
+			if tt.method == "POST" && tt.wantStatus == 0 && len(server.data.Runs) != queued+1 {
+				t.Errorf("submission added %d runs, want 1", len(server.data.Runs)-queued)
+			}
+		})
+	}
+}
+
+func TestSecondsToPT(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "PT0S"},
+		{59, "PT59S"},
+		{90.5, "PT1M30.5S"},
+		{3600, "PT1H"},
+		{3661, "PT1H1M1S"},
+		{5025.678, "PT1H23M45.678S"},
+		{1590.44, "PT26M30.44S"},
+	}
+	
+	for _, tt := range tests {
+		if got := secondsToPT(tt.seconds); got != tt.want {
+			t.Errorf("secondsToPT(%v) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
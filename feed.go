@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Feeds list the newest verified runs of a game, a category, or a user as
+// Atom or RSS, so feed readers can follow a board instead of polling the
+// site.
+
+const (
+	DefaultFeedEntries = 20
+	MaxFeedEntries     = 200
+	AtomContentType    = "application/atom+xml; charset=utf-8"
+	RSSContentType     = "application/rss+xml; charset=utf-8"
+)
+
+// Feed is the format-independent content of a feed.
+type Feed struct {
+	Title   string
+	ID      string
+	Link    string
+	Updated time.Time
+	Entries []FeedEntry
+}
+
+type FeedEntry struct {
+	ID      string
+	Title   string
+	Link    string
+	Video   string
+	Runners []string
+	Time    string
+	Place   int
+	Updated time.Time
+	Summary string
+}
+
+func handleFeed(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("feed", flag.ExitOnError)
+	format := fs.String("format", "atom", "feed format: atom or rss")
+	output := fs.String("output", "", "write the feed to FILE instead of stdout")
+	max := fs.Int("max", DefaultFeedEntries, fmt.Sprintf("number of runs (at most %d)", MaxFeedEntries))
+	userName := fs.String("user", "", "feed a user's runs instead of a game's")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli feed [flags] <game> [category]")
+		fmt.Fprintln(fs.Output(), "       speedrun-cli feed [flags] --user <name>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if (*userName == "") == (fs.NArg() == 0) {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *format != "atom" && *format != "rss" {
+		fmt.Printf("Error: unknown feed format %q (use atom or rss)\n", *format)
+		os.Exit(1)
+	}
+	if *max < 1 || *max > MaxFeedEntries {
+		fmt.Printf("Error: -max must be between 1 and %d\n", MaxFeedEntries)
+		os.Exit(1)
+	}
+	
+	var feed *Feed
+	var err error
+	if *userName != "" {
+		feed, err = buildUserFeed(api, *userName, *max)
+	} else {
+		feed, err = buildGameFeed(api, fs.Arg(0), strings.Join(fs.Args()[1:], " "), *max)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	document, _, err := renderFeed(feed, *format)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	if *output == "" {
+		os.Stdout.Write(document)
+		return
+	}
+	if err := writeFileAtomic(*output, document); err != nil {
+		fmt.Printf("Error writing %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%sWrote %d runs to %s\n", icon("✅"), len(feed.Entries), *output)
+}
+
+// buildGameFeed feeds a game's newest runs, or one category's when
+// categoryName is set.
+func buildGameFeed(api *SpeedrunAPI, gameName, categoryName string, max int) (*Feed, error) {
+	game, err := api.GetGame(gameName)
+	if err != nil {
+		return nil, err
+	}
+	
+	filter := url.Values{"game": {game.ID}}
+	feed := &Feed{
+		Title: "speedrun.com: " + game.Names.International,
+		ID:    game.Weblink,
+		Link:  game.Weblink,
+	}
+	
+	if categoryName != "" {
+		categories, err := api.GetGameCategories(game.ID)
+		if err != nil {
+			return nil, err
+		}
+		category := matchCategoryFragment(categories, categoryName)
+		if category == nil {
+			return nil, fmt.Errorf("unknown category %q (available: %s)", categoryName, strings.Join(categoryNames(categories), ", "))
+		}
+		filter.Set("category", category.ID)
+		feed.Title += " - " + category.Name
+		feed.ID, feed.Link = category.Weblink, category.Weblink
+	}
+	
+	runs, err := api.GetRecentRuns(filter, max)
+	if err != nil {
+		return nil, err
+	}
+	feed.Entries = feedEntries(api, runs)
+	feed.Updated = feedUpdated(feed.Entries)
+	return feed, nil
+}
+
+func buildUserFeed(api *SpeedrunAPI, userName string, max int) (*Feed, error) {
+	user, err := api.GetUser(userName)
+	if err != nil {
+		return nil, err
+	}
+	
+	runs, err := api.GetRecentRuns(url.Values{"user": {user.ID}}, max)
+	if err != nil {
+		return nil, err
+	}
+	
+	link := "https://www.speedrun.com/users/" + url.PathEscape(user.Names.International)
+	feed := &Feed{
+		Title:   "speedrun.com: runs by " + user.Names.International,
+		ID:      link,
+		Link:    link,
+		Entries: feedEntries(api, runs),
+	}
+	feed.Updated = feedUpdated(feed.Entries)
+	return feed, nil
+}
+
+// feedEntries resolves each run's game, category, level, subcategory,
+// runners, platform, and current place into a feed entry.
+func feedEntries(api *SpeedrunAPI, runs []Run) []FeedEntry {
+	places := api.GetRunPlaces(runs)
+	games := make(map[string]string)
+	names := make(map[string]string)
+	platforms := make(map[string]string)
+	
+	entries := make([]FeedEntry, 0, len(runs))
+	for _, run := range runs {
+		if _, loaded := games[run.Game]; !loaded {
+			games[run.Game] = run.Game
+			if game, err := api.GetGame(run.Game); err == nil {
+				games[run.Game] = game.Names.International
+			}
+			for id, name := range loadRunNames(api, run.Game, runs) {
+				names[id] = name
+			}
+			if gamePlatforms, err := api.GetGamePlatforms(run.Game); err == nil {
+				for _, platform := range gamePlatforms {
+					platforms[platform.ID] = platform.Name
+				}
+			}
+		}
+		
+		board := games[run.Game] + " - " + names[run.Category]
+		if run.Level != "" {
+			board += " (" + names[run.Level] + ")"
+		}
+		if labels := subcategoryLabels(api, run.Category, run.Values); len(labels) > 0 {
+			var values []string
+			for _, label := range labels {
+				values = append(values, label)
+			}
+			sort.Strings(values)
+			board += " [" + strings.Join(values, ", ") + "]"
+		}
+		
+		entry := FeedEntry{
+			ID:      run.Weblink,
+			Link:    run.Weblink,
+			Runners: runPlayerNames(api, run, nil),
+			Time:    formatTime(run.Times.Primary),
+			Place:   places[run.ID],
+			Updated: run.Submitted,
+		}
+		if entry.Updated.IsZero() {
+			entry.Updated, _ = time.Parse("2006-01-02", run.Date)
+		}
+		if len(run.Videos.Links) > 0 {
+			entry.Video = run.Videos.Links[0].URI
+		}
+		
+		runners := strings.Join(entry.Runners, ", ")
+		entry.Title = fmt.Sprintf("%s: %s by %s", board, entry.Time, runners)
+		summary := fmt.Sprintf("%s by %s in %s", entry.Time, runners, board)
+		if entry.Place > 0 {
+			entry.Title += fmt.Sprintf(" (%s)", ordinal(entry.Place))
+			summary += fmt.Sprintf(", currently %s place", ordinal(entry.Place))
+		}
+		summary += fmt.Sprintf(". Played on %s", getPlatformName(run, platforms))
+		if run.Date != "" {
+			summary += " on " + run.Date
+		}
+		summary += "."
+		if entry.Video != "" {
+			summary += " Video: " + entry.Video
+		}
+		entry.Summary = summary
+		
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func feedUpdated(entries []FeedEntry) time.Time {
+	var updated time.Time
+	for _, entry := range entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	return updated
+}
+
+// renderFeed encodes a feed as "atom" or "rss", returning the document and
+// its content type.
+func renderFeed(feed *Feed, format string) ([]byte, string, error) {
+	var document interface{}
+	var contentType string
+	switch format {
+	case "atom":
+		document, contentType = newAtomFeed(feed), AtomContentType
+	case "rss":
+		document, contentType = newRSSFeed(feed), RSSContentType
+	default:
+		return nil, "", fmt.Errorf("unknown feed format %q (use atom or rss)", format)
+	}
+	
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, "", err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), contentType, nil
+}
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string       `xml:"title"`
+	ID      string       `xml:"id"`
+	Updated string       `xml:"updated"`
+	Links   []atomLink   `xml:"link"`
+	Authors []atomPerson `xml:"author"`
+	Summary string       `xml:"summary"`
+}
+
+func newAtomFeed(feed *Feed) atomFeed {
+	result := atomFeed{
+		Title:     feed.Title,
+		ID:        feed.ID,
+		Updated:   feed.Updated.UTC().Format(time.RFC3339),
+		Links:     []atomLink{{Href: feed.Link, Rel: "alternate"}},
+		Generator: "speedrun-cli " + Version,
+	}
+	for _, entry := range feed.Entries {
+		atom := atomEntry{
+			Title:   entry.Title,
+			ID:      entry.ID,
+			Updated: entry.Updated.UTC().Format(time.RFC3339),
+			Links:   []atomLink{{Href: entry.Link, Rel: "alternate"}},
+			Summary: entry.Summary,
+		}
+		if entry.Video != "" {
+			atom.Links = append(atom.Links, atomLink{Href: entry.Video, Rel: "related"})
+		}
+		for _, runner := range entry.Runners {
+			atom.Authors = append(atom.Authors, atomPerson{Name: runner})
+		}
+		result.Entries = append(result.Entries, atom)
+	}
+	return result
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+func newRSSFeed(feed *Feed) rssFeed {
+	channel := rssChannel{
+		Title:         feed.Title,
+		Link:          feed.Link,
+		Description:   feed.Title,
+		LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+		Generator:     "speedrun-cli " + Version,
+	}
+	for _, entry := range feed.Entries {
+		channel.Items = append(channel.Items, rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			GUID:        rssGUID{Value: entry.ID, IsPermaLink: true},
+			PubDate:     entry.Updated.UTC().Format(time.RFC1123Z),
+			Description: entry.Summary,
+		})
+	}
+	return rssFeed{Version: "2.0", Channel: channel}
+}
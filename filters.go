@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RunFilter is one parsed filter expression, e.g. "platform:n64".
+type RunFilter struct {
+	Expr  string
+	Match func(run Run, lb *Leaderboard) bool
+}
+
+// parseFilterExpression parses space-separated filter terms:
+//
+//	platform:N64  runner:name  date>2023-01-01  date<=2020-12-31
+//	video  !video  emu  !emu
+//
+// Values containing spaces can be quoted: platform:"PlayStation 2".
+func parseFilterExpression(input string) ([]RunFilter, error) {
+	var filters []RunFilter
+	
+	for _, term := range splitFilterTerms(input) {
+		filter, err := parseFilterTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+	return filters, nil
+}
+
+func splitFilterTerms(input string) []string {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+	
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ' ' && !inQuotes:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms
+}
+
+func parseFilterTerm(term string) (RunFilter, error) {
+	lower := strings.ToLower(term)
+	
+	switch lower {
+	case "video":
+		return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool { return hasVideo(run) }}, nil
+	case "!video", "novideo":
+		return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool { return !hasVideo(run) }}, nil
+	case "emu":
+		return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool { return run.System.Emulated }}, nil
+	case "!emu", "noemu":
+		return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool { return !run.System.Emulated }}, nil
+	}
+	
+	if key, value, ok := strings.Cut(lower, ":"); ok && value != "" {
+		switch key {
+		case "platform":
+			return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool {
+				return strings.Contains(strings.ToLower(getPlatformName(run, lb.PlatformMap)), value)
+			}}, nil
+		case "runner", "player":
+			return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool {
+				return strings.Contains(strings.ToLower(leaderboardPlayerName(run, lb)), value)
+			}}, nil
+		}
+	}
+	
+	if strings.HasPrefix(lower, "date") {
+		rest := lower[len("date"):]
+		for _, op := range []string{">=", "<=", ">", "<", "="} {
+			if !strings.HasPrefix(rest, op) {
+				continue
+			}
+			
+			value := strings.TrimSpace(rest[len(op):])
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return RunFilter{}, fmt.Errorf("invalid date in %q (expected YYYY-MM-DD)", term)
+			}
+			
+			op := op
+			return RunFilter{Expr: term, Match: func(run Run, lb *Leaderboard) bool {
+				runDate, err := time.Parse("2006-01-02", run.Date)
+				if err != nil {
+					return false
+				}
+				switch op {
+				case ">=":
+					return !runDate.Before(date)
+				case "<=":
+					return !runDate.After(date)
+				case ">":
+					return runDate.After(date)
+				case "<":
+					return runDate.Before(date)
+				default:
+					return runDate.Equal(date)
+				}
+			}}, nil
+		}
+	}
+	
+	return RunFilter{}, fmt.Errorf("unknown filter %q", term)
+}
+
+func hasVideo(run Run) bool {
+	return len(run.Videos.Links) > 0 && run.Videos.Links[0].URI != ""
+}
+
+func needsPlayerNames(filters []RunFilter) bool {
+	for _, filter := range filters {
+		lower := strings.ToLower(filter.Expr)
+		if strings.HasPrefix(lower, "runner:") || strings.HasPrefix(lower, "player:") {
+			return true
+		}
+	}
+	return false
+}
+
+// leaderboardView holds the leaderboard screen's in-memory search and
+// filter state. Filtering narrows full.Runs without refetching; runs keep
+// their original place.
+type leaderboardView struct {
+	full      *Leaderboard
+	shown     *Leaderboard
+	filters   []RunFilter
+	highlight int
+	lastQuery string
+}
+
+func newLeaderboardView(lb *Leaderboard) *leaderboardView {
+	return &leaderboardView{full: lb, shown: lb, highlight: -1}
+}
+
+func (v *leaderboardView) applyFilter(api *SpeedrunAPI, expr string) error {
+	filters, err := parseFilterExpression(expr)
+	if err != nil {
+		return err
+	}
+	
+	if needsPlayerNames(filters) {
+		if err := api.LoadPlayerNames(v.full); err != nil {
+			return err
+		}
+	}
+	
+	v.filters = append(v.filters, filters...)
+	v.refilter()
+	return nil
+}
+
+func (v *leaderboardView) clearFilters() {
+	v.filters = nil
+	v.refilter()
+}
+
+func (v *leaderboardView) refilter() {
+	v.highlight = -1
+	if len(v.filters) == 0 {
+		v.shown = v.full
+		return
+	}
+	
+	filtered := *v.full
+	filtered.Runs = filtered.Runs[:0:0]
+	for _, entry := range v.full.Runs {
+		matches := true
+		for _, filter := range v.filters {
+			if !filter.Match(entry.Run, v.full) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered.Runs = append(filtered.Runs, entry)
+		}
+	}
+	
+	exprs := make([]string, len(v.filters))
+	for i, filter := range v.filters {
+		exprs[i] = filter.Expr
+	}
+	filtered.Filter = strings.Join(exprs, " ")
+	filtered.TotalRuns = len(v.full.Runs)
+	v.shown = &filtered
+}
+
+// search finds the next runner whose name contains query, after the
+// currently highlighted row and wrapping around, and returns its page.
+// An empty query repeats the last search. Names come from the board's
+// players embed only, never from a lookup per row.
+func (v *leaderboardView) search(api *SpeedrunAPI, query string) (int, bool, error) {
+	if query == "" {
+		query = v.lastQuery
+	}
+	if query == "" {
+		return 0, false, nil
+	}
+	v.lastQuery = query
+	
+	if err := api.LoadPlayerNames(v.full); err != nil {
+		return 0, false, err
+	}
+	
+	query = strings.ToLower(query)
+	runs := v.shown.Runs
+	for offset := 1; offset <= len(runs); offset++ {
+		idx := (v.highlight + offset) % len(runs)
+		if idx < 0 {
+			idx += len(runs)
+		}
+		if strings.Contains(strings.ToLower(boardRunnerNames(runs[idx].Run, v.full)), query) {
+			v.highlight = idx
+			return idx/leaderboardPageSize() + 1, true, nil
+		}
+	}
+	
+	return 0, false, nil
+}
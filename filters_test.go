@@ -0,0 +1,147 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// boardView loads the fake server's sm64 120 Star board into a fresh
+// leaderboard view, as the leaderboard screen does.
+func boardView(t *testing.T) (*SpeedrunAPI, *leaderboardView) {
+	t.Helper()
+	api, _ := startFakeAPI(t, fakeFaults{})
+	lb, err := api.GetLeaderboard("o1y9wo6q", "wkpoo02r", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lb.Runs) != 40 {
+		t.Fatalf("board has %d runs, want 40", len(lb.Runs))
+	}
+	return api, newLeaderboardView(lb)
+}
+
+func shownPlaces(v *leaderboardView) []int {
+	var places []int
+	for _, entry := range v.shown.Runs {
+		places = append(places, entry.Place)
+	}
+	return places
+}
+
+func TestLeaderboardViewFilters(t *testing.T) {
+	tests := []struct {
+		name       string
+		exprs      []string // applied one after another
+		wantPlaces []int
+		wantFilter string
+	}{
+		{name: "platform", exprs: []string{"platform:WII"}, wantPlaces: []int{4, 8, 17, 18, 20, 21, 22, 23, 25, 26, 28, 30, 31, 35, 37, 38, 40}, wantFilter: "platform:WII"},
+		{name: "quoted platform", exprs: []string{`platform:"Wii U Virtual Console"`}, wantPlaces: []int{22, 23, 26, 28, 30}, wantFilter: "platform:Wii U Virtual Console"},
+		{name: "emulator", exprs: []string{"emu"}, wantPlaces: []int{8, 21}, wantFilter: "emu"},
+		{name: "no video", exprs: []string{"!video"}, wantPlaces: []int{5, 14, 23, 33}, wantFilter: "!video"},
+		{name: "runner loads names", exprs: []string{"runner:WEE"}, wantPlaces: []int{3}, wantFilter: "runner:WEE"},
+		{name: "date", exprs: []string{"date>=2025-01-01"}, wantPlaces: []int{2, 4, 12, 23, 28, 33, 35}, wantFilter: "date>=2025-01-01"},
+		{name: "exact date", exprs: []string{"date=2016-12-09"}, wantPlaces: []int{1}, wantFilter: "date=2016-12-09"},
+		{name: "filters stack", exprs: []string{"date>=2025-01-01", "platform:nintendo novideo"}, wantPlaces: []int{33}, wantFilter: "date>=2025-01-01 platform:nintendo novideo"},
+		{name: "nothing matches", exprs: []string{"emu date<2017-01-01 !emu"}, wantPlaces: nil, wantFilter: "emu date<2017-01-01 !emu"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, view := boardView(t)
+			for _, expr := range tt.exprs {
+				if err := view.applyFilter(api, expr); err != nil {
+					t.Fatalf("applyFilter(%q) returned error: %v", expr, err)
+				}
+			}
+			
+			// Runs keep the place they hold on the full board
+			if got := shownPlaces(view); !reflect.DeepEqual(got, tt.wantPlaces) {
+				t.Errorf("shown places = %v, want %v", got, tt.wantPlaces)
+			}
+			if view.shown.Filter != tt.wantFilter {
+				t.Errorf("Filter = %q, want %q", view.shown.Filter, tt.wantFilter)
+			}
+			if view.shown.TotalRuns != 40 || len(view.full.Runs) != 40 {
+				t.Errorf("TotalRuns = %d, full board has %d runs, want 40", view.shown.TotalRuns, len(view.full.Runs))
+			}
+			
+			view.clearFilters()
+			if view.shown != view.full || view.shown.Filter != "" {
+				t.Errorf("clearFilters() left %d runs shown with filter %q", len(view.shown.Runs), view.shown.Filter)
+			}
+		})
+	}
+}
+
+func TestLeaderboardViewBadFilter(t *testing.T) {
+	for _, expr := range []string{"", "   ", "fast", "platform:", "date>2021-13-01", "date~2021-01-01"} {
+		api, view := boardView(t)
+		if err := view.applyFilter(api, "emu"); err != nil {
+			t.Fatal(err)
+		}
+		if err := view.applyFilter(api, expr); err == nil {
+			t.Errorf("applyFilter(%q) succeeded, want an error", expr)
+		}
+		// A bad expression leaves the current filters alone
+		if got := shownPlaces(view); !reflect.DeepEqual(got, []int{8, 21}) || view.shown.Filter != "emu" {
+			t.Errorf("after applyFilter(%q): places %v, filter %q", expr, got, view.shown.Filter)
+		}
+	}
+}
+
+func TestLeaderboardViewSearch(t *testing.T) {
+	api, view := boardView(t)
+	plainOutput(t, 80, LeaderboardChromeLines+10) // ten runs per page
+	
+	type step struct {
+		query     string
+		wantPage  int
+		wantFound bool
+		wantPlace int
+	}
+	check := func(steps []step) {
+		t.Helper()
+		for _, s := range steps {
+			page, found, err := view.search(api, s.query)
+			if err != nil {
+				t.Fatalf("search(%q) returned error: %v", s.query, err)
+			}
+			if found != s.wantFound || page != s.wantPage {
+				t.Errorf("search(%q) = page %d, found %v; want page %d, found %v", s.query, page, found, s.wantPage, s.wantFound)
+				continue
+			}
+			if found {
+				if place := view.shown.Runs[view.highlight].Place; place != s.wantPlace {
+					t.Errorf("search(%q) highlighted place %d, want %d", s.query, place, s.wantPlace)
+				}
+			}
+		}
+	}
+	
+	// Tiramisu, then Mirrorblade, and around again
+	check([]step{
+		{query: "", wantFound: false},
+		{query: "MI", wantPage: 2, wantFound: true, wantPlace: 20},
+		{query: "", wantPage: 3, wantFound: true, wantPlace: 29},
+		{query: "", wantPage: 2, wantFound: true, wantPlace: 20},
+		{query: "fan", wantPage: 4, wantFound: true, wantPlace: 39},
+		{query: "nobody", wantFound: false},
+	})
+	
+	// Filtering resets the highlight and searches only the shown runs, so
+	// pages count filtered rows
+	if err := view.applyFilter(api, "platform:wii"); err != nil {
+		t.Fatal(err)
+	}
+	if view.highlight != -1 {
+		t.Errorf("highlight = %d after filtering, want -1", view.highlight)
+	}
+	check([]step{
+		{query: "mi", wantPage: 1, wantFound: true, wantPlace: 20},
+		{query: "", wantPage: 1, wantFound: true, wantPlace: 20},
+		{query: "choco", wantPage: 2, wantFound: true, wantPlace: 30},
+		{query: "simply", wantFound: false},
+		{query: "", wantFound: false},
+	})
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Fixtures are recorded HTTP exchanges, one JSON file per request, named
+// <method>_<path>_<hash>_<n>.json. The hash covers the method, path,
+// query, and request body; n counts repeats of the same request, so a
+// refreshed leaderboard replays the second response it got. Fixtures do
+// not include the API key or the host, so a session recorded against one
+// server replays against any other with the same API path.
+
+const MaxFixtureSlugLength = 60
+
+var fixtureSlugPattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// Fixture is one recorded request and its response.
+type Fixture struct {
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	Status       int             `json:"status"`
+	Header       http.Header     `json:"header"`
+	ResponseBody json.RawMessage `json:"response_body"`
+}
+
+// fixtureSequence hands out the repeat count for each request key.
+type fixtureSequence struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (s *fixtureSequence) next(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[key]++
+	return s.counts[key]
+}
+
+func fixtureKey(method, path string, body []byte) string {
+	sum := sha256.Sum256([]byte(method + " " + path + "\n" + string(body)))
+	
+	slug := strings.Trim(fixtureSlugPattern.ReplaceAllString(strings.SplitN(path, "?", 2)[0], "_"), "_")
+	if len(slug) > MaxFixtureSlugLength {
+		slug = slug[:MaxFixtureSlugLength]
+	}
+	return fmt.Sprintf("%s_%s_%s", method, slug, hex.EncodeToString(sum[:6]))
+}
+
+func fixturePath(dir, key string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s_%d.json", key, n))
+}
+
+// readRequestBody reads and restores a request body so it can still be
+// sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// rawJSON keeps valid JSON bodies as-is in the fixture and stores anything
+// else as a JSON string.
+func rawJSON(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return body
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+// recordingTransport passes requests through and writes each exchange to
+// the fixture directory.
+type recordingTransport struct {
+	dir      string
+	next     http.RoundTripper
+	sequence fixtureSequence
+}
+
+func newRecordingTransport(dir string, next http.RoundTripper) *recordingTransport {
+	return &recordingTransport{dir: dir, next: next, sequence: fixtureSequence{counts: make(map[string]int)}}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	
+	path := req.URL.RequestURI()
+	fixture := Fixture{
+		Method:       req.Method,
+		Path:         path,
+		RequestBody:  rawJSON(requestBody),
+		Status:       resp.StatusCode,
+		Header:       header,
+		ResponseBody: rawJSON(body),
+	}
+	
+	key := fixtureKey(req.Method, path, requestBody)
+	if err := writeFixture(fixturePath(t.dir, key, t.sequence.next(key)), fixture); err != nil {
+		logger.Warn("failed to record fixture", "path", path, "error", err)
+	}
+	return resp, nil
+}
+
+func writeFixture(path string, fixture Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// replayTransport answers requests from the fixture directory and never
+// touches the network.
+type replayTransport struct {
+	dir      string
+	sequence fixtureSequence
+}
+
+func newReplayTransport(dir string) *replayTransport {
+	return &replayTransport{dir: dir, sequence: fixtureSequence{counts: make(map[string]int)}}
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	
+	path := req.URL.RequestURI()
+	key := fixtureKey(req.Method, path, requestBody)
+	
+	// Past the last recorded repeat, keep serving the last one
+	n := t.sequence.next(key)
+	fixture, err := readFixture(fixturePath(t.dir, key, n))
+	for n--; os.IsNotExist(err) && n > 0; n-- {
+		fixture, err = readFixture(fixturePath(t.dir, key, n))
+	}
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no recorded response for %s %s in %s", req.Method, path, t.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	body := []byte(fixture.ResponseBody)
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+	
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        fixture.Header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func readFixture(path string) (Fixture, error) {
+	var fixture Fixture
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fixture, err
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return fixture, fmt.Errorf("reading fixture %s: %w", path, err)
+	}
+	return fixture, nil
+}
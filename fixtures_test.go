@@ -0,0 +1,53 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFixtureKey(t *testing.T) {
+	keyPattern := regexp.MustCompile(`^([A-Z]+)_(.*)_([0-9a-f]{12})$`)
+	
+	tests := []struct {
+		method   string
+		path     string
+		body     string
+		wantSlug string
+	}{
+		{method: "GET", path: "/api/v1/games/sm64", wantSlug: "api_v1_games_sm64"},
+		{method: "GET", path: "/api/v1/leaderboards/o1y9wo6q/category/wkpoo02r?embed=players&var-e8m7em86=9qj7z0oq", wantSlug: "api_v1_leaderboards_o1y9wo6q_category_wkpoo02r"},
+		{method: "POST", path: "/api/v1/runs", body: `{"run":{}}`, wantSlug: "api_v1_runs"},
+		{method: "GET", path: "/api/v1/" + strings.Repeat("x", 80), wantSlug: ("api_v1_" + strings.Repeat("x", 80))[:MaxFixtureSlugLength]},
+	}
+	
+	for _, tt := range tests {
+		key := fixtureKey(tt.method, tt.path, []byte(tt.body))
+		match := keyPattern.FindStringSubmatch(key)
+		if match == nil {
+			t.Errorf("fixtureKey(%s, %s) = %q, want method_slug_hash", tt.method, tt.path, key)
+			continue
+		}
+		if match[1] != tt.method || match[2] != tt.wantSlug {
+			t.Errorf("fixtureKey(%s, %s) = %q, want slug %q", tt.method, tt.path, key, tt.wantSlug)
+		}
+		if again := fixtureKey(tt.method, tt.path, []byte(tt.body)); again != key {
+			t.Errorf("fixtureKey(%s, %s) is not stable: %q then %q", tt.method, tt.path, key, again)
+		}
+	}
+	
+	// The query and body are part of the hash even though not of the slug.
+	distinct := []string{
+		fixtureKey("GET", "/api/v1/runs?offset=0", nil),
+		fixtureKey("GET", "/api/v1/runs?offset=20", nil),
+		fixtureKey("POST", "/api/v1/runs?offset=0", nil),
+		fixtureKey("GET", "/api/v1/runs?offset=0", []byte("{}")),
+	}
+	seen := make(map[string]bool)
+	for _, key := range distinct {
+		if seen[key] {
+			t.Errorf("fixtureKey collision: %q", key)
+		}
+		seen[key] = true
+	}
+}
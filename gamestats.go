@@ -0,0 +1,415 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Game stats sum up every full-game category of a game: how popular each
+// category is, who holds the most records and podium spots, which
+// platforms runs are on, and which records have stood the longest. Every
+// subcategory has its own board and record, so one board per subcategory
+// value is fetched, concurrently and under the request rate limit.
+
+const (
+	DefaultStatsTopRunners = 10
+	DefaultStatsStaleWRs   = 5
+	ShareBarWidth          = 20
+)
+
+// GameStats aggregates the boards of a game's full-game categories. Runs
+// counts every verified run of the game, up to MaxGameStatsRuns; BoardRuns
+// only the runs on the boards, one per runner and board.
+type GameStats struct {
+	Game       *Game
+	Categories int
+	Boards     []CategoryStats
+	Runs       int
+	RunsCapped bool
+	BoardRuns  int
+	Runners    int
+	TopRunners []RunnerStats
+	Platforms  []PlatformShare
+}
+
+// CategoryStats is one board's size and record: a category, or one of its
+// subcategories.
+type CategoryStats struct {
+	Category    Category
+	Subcategory string
+	Runners     int
+	Record      *Run
+}
+
+// Name is the category's name with the subcategory label, if any.
+func (c CategoryStats) Name() string {
+	if c.Subcategory != "" {
+		return c.Category.Name + " (" + c.Subcategory + ")"
+	}
+	return c.Category.Name
+}
+
+// RunnerStats counts a runner's records and podium places across the
+// game's boards. Co-op runs count for every runner.
+type RunnerStats struct {
+	Name    string
+	WRs     int
+	Podiums int
+	Boards  int
+}
+
+// PlatformShare counts the runs on the boards done on one platform.
+type PlatformShare struct {
+	Name string
+	Runs int
+}
+
+func handleGameStats(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("game-stats", flag.ExitOnError)
+	top := fs.Int("top", DefaultStatsTopRunners, "number of top runners to show")
+	stale := fs.Int("stale", DefaultStatsStaleWRs, "number of oldest records to show")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli game-stats [flags] <game>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if fs.NArg() < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	
+	game, err := api.GetGame(strings.Join(fs.Args(), " "))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	showSnapshotAge(api, game.ID)
+	
+	stats, err := loadGameStats(api, game, *top)
+	if err != nil {
+		fmt.Printf("Error loading game stats: %v\n", err)
+		os.Exit(1)
+	}
+	displayGameStats(stats, *stale)
+}
+
+// loadGameStats fetches the board of every full-game category and
+// subcategory, at most LeaderboardFetchWorkers at a time, counts the game's
+// verified runs, and aggregates them. Only the top runners' names are
+// looked up.
+func loadGameStats(api *SpeedrunAPI, game *Game, topRunners int) (*GameStats, error) {
+	categories, err := api.GetGameCategories(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	stats := &GameStats{Game: game}
+	var requests []boardRequest
+	for _, category := range categories {
+		if category.Type != "per-game" {
+			continue
+		}
+		stats.Categories++
+		variables, err := api.GetVariables(category.ID)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, subcategoryBoards(*game, category, variables)...)
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("%s has no full-game categories", game.Names.International)
+	}
+	
+	showProgress("⏳", "Loading %d leaderboards...", len(requests))
+	boards := api.fetchLeaderboards(requests)
+	clearProgress()
+	
+	if stats.Runs, stats.RunsCapped, err = api.CountGameRuns(game.ID); err != nil {
+		return nil, err
+	}
+	
+	loaded := 0
+	runners := make(map[string]*RunnerStats)
+	platforms := make(map[string]int)
+	
+	for i, board := range boards {
+		summary := CategoryStats{Category: requests[i].Category, Subcategory: requests[i].Label}
+		if board == nil {
+			stats.Boards = append(stats.Boards, summary)
+			continue
+		}
+		loaded++
+		summary.Runners = len(board.Runs)
+		if len(board.Runs) > 0 {
+			summary.Record = &board.Runs[0].Run
+		}
+		stats.Boards = append(stats.Boards, summary)
+		
+		onBoard := make(map[string]bool)
+		for _, entry := range board.Runs {
+			stats.BoardRuns++
+			platforms[getPlatformName(entry.Run, board.PlatformMap)]++
+			
+			for _, player := range entry.Run.Players {
+				key, name := "guest:"+strings.ToLower(player.Name), player.Name
+				if player.ID != "" {
+					key, name = player.ID, ""
+				}
+				runner := runners[key]
+				if runner == nil {
+					runner = &RunnerStats{Name: name}
+					runners[key] = runner
+				}
+				if !onBoard[key] {
+					onBoard[key] = true
+					runner.Boards++
+				}
+				if entry.Place == 1 {
+					runner.WRs++
+				}
+				if entry.Place >= 1 && entry.Place <= MaxRankWithMedal {
+					runner.Podiums++
+				}
+			}
+		}
+	}
+	stats.Runners = len(runners)
+	
+	if loaded == 0 {
+		return nil, fmt.Errorf("no leaderboards could be loaded for %s", game.Names.International)
+	}
+	
+	keys := make([]string, 0, len(runners))
+	for key, runner := range runners {
+		if runner.Podiums > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := runners[keys[i]], runners[keys[j]]
+		if a.WRs != b.WRs {
+			return a.WRs > b.WRs
+		}
+		if a.Podiums != b.Podiums {
+			return a.Podiums > b.Podiums
+		}
+		if a.Boards != b.Boards {
+			return a.Boards > b.Boards
+		}
+		return keys[i] < keys[j]
+	})
+	if topRunners >= 0 && len(keys) > topRunners {
+		keys = keys[:topRunners]
+	}
+	for _, key := range keys {
+		runner := runners[key]
+		if runner.Name == "" {
+			runner.Name = key
+			if user := api.GetUserData(key); user != nil && user.Names.International != "" {
+				runner.Name = user.Names.International
+			}
+		}
+		stats.TopRunners = append(stats.TopRunners, *runner)
+	}
+	
+	for name, runs := range platforms {
+		stats.Platforms = append(stats.Platforms, PlatformShare{Name: name, Runs: runs})
+	}
+	sort.Slice(stats.Platforms, func(i, j int) bool {
+		if stats.Platforms[i].Runs != stats.Platforms[j].Runs {
+			return stats.Platforms[i].Runs > stats.Platforms[j].Runs
+		}
+		return stats.Platforms[i].Name < stats.Platforms[j].Name
+	})
+	
+	return stats, nil
+}
+
+// subcategoryBoards requests one board per value of the category's
+// subcategory variable, sorted by label, or the category's board when it
+// has none. Like leaderboards, only the first subcategory variable splits
+// the board.
+func subcategoryBoards(game Game, category Category, variables []Variable) []boardRequest {
+	for _, variable := range variables {
+		if !variable.IsSubcategory || len(variable.Values.Values) == 0 {
+			continue
+		}
+		var requests []boardRequest
+		for valueID, value := range variable.Values.Values {
+			requests = append(requests, boardRequest{
+				Game:     game,
+				Category: category,
+				Values:   map[string]string{variable.ID: valueID},
+				Label:    value.Label,
+			})
+		}
+		sort.Slice(requests, func(i, j int) bool { return requests[i].Label < requests[j].Label })
+		return requests
+	}
+	return []boardRequest{{Game: game, Category: category}}
+}
+
+func displayGameStats(stats *GameStats, staleCount int) {
+	colors := theme.Colors
+	
+	fmt.Printf("\n%s%s - Game Stats\n", icon("🎮"), stats.Game.Names.International)
+	runs := fmt.Sprintf("%d", stats.Runs)
+	if stats.RunsCapped {
+		runs += "+"
+	}
+	fmt.Printf("%s%d full-game categories, %s verified runs (%d on the full-game boards), %d runners\n",
+		icon("📊"), stats.Categories, runs, stats.BoardRuns, stats.Runners)
+	
+	fmt.Printf("\n%sCategories by Runners\n", icon("🏷️"))
+	displayCategoryPopularity(stats.Boards)
+	
+	fmt.Printf("\n%sTop Runners\n", icon("🏆"))
+	if len(stats.TopRunners) == 0 {
+		fmt.Println("No podium finishes yet.")
+	} else {
+		names := make([]string, len(stats.TopRunners))
+		for i, runner := range stats.TopRunners {
+			names[i] = runner.Name
+		}
+		columns := []TableColumn{
+			{Title: "#", Width: 4},
+			textColumn("Runner", names, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+			{Title: "WRs", Width: 5},
+			{Title: "Top 3", Width: 6},
+			{Title: "Boards", Width: 6},
+		}
+		printStatsTable(columns, len(stats.TopRunners), func(i int) []string {
+			runner := stats.TopRunners[i]
+			wrs := fmt.Sprintf("%d", runner.WRs)
+			if runner.WRs > 0 {
+				wrs = colors.Gold + wrs + colors.Reset
+			}
+			return []string{fmt.Sprintf("%d", i+1), names[i], wrs, fmt.Sprintf("%d", runner.Podiums), fmt.Sprintf("%d", runner.Boards)}
+		})
+	}
+	
+	fmt.Printf("\n%sPlatforms\n", icon("🕹️"))
+	if stats.BoardRuns == 0 {
+		fmt.Println("No runs on the boards.")
+	} else {
+		names := make([]string, len(stats.Platforms))
+		for i, platform := range stats.Platforms {
+			names[i] = platform.Name
+		}
+		columns := []TableColumn{
+			textColumn("Platform", names, DefaultColumnWidth, MinTextColumnWidth, 0),
+			{Title: "Runs", Width: 6},
+			{Title: "Share", Width: 6},
+			{Title: "", Width: ShareBarWidth, DropRank: 1},
+		}
+		printStatsTable(columns, len(stats.Platforms), func(i int) []string {
+			platform := stats.Platforms[i]
+			share := float64(platform.Runs) / float64(stats.BoardRuns)
+			bar := strings.Repeat(symbol("█", "#"), int(share*ShareBarWidth+0.5))
+			return []string{names[i], fmt.Sprintf("%d", platform.Runs), fmt.Sprintf("%.1f%%", share*100), bar}
+		})
+	}
+	
+	fmt.Printf("\n%sStalest Records\n", icon("🕰️"))
+	displayStaleRecords(stats.Boards, staleCount)
+}
+
+func displayCategoryPopularity(categories []CategoryStats) {
+	sorted := append([]CategoryStats(nil), categories...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Runners > sorted[j].Runners })
+	
+	names := make([]string, len(sorted))
+	for i, summary := range sorted {
+		names[i] = summary.Name()
+		if summary.Category.Miscellaneous {
+			names[i] += " (misc)"
+		}
+	}
+	columns := []TableColumn{
+		textColumn("Category", names, CategoryColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Runners", Width: 7},
+		{Title: "WR", Width: TimeColumnWidth},
+		{Title: "Date", Width: DateColumnWidth, DropRank: 1},
+	}
+	printStatsTable(columns, len(sorted), func(i int) []string {
+		record, date := EmptyValuePlaceholder, EmptyValuePlaceholder
+		if sorted[i].Record != nil {
+			record, date = getBestTime(*sorted[i].Record), sorted[i].Record.Date
+		}
+		return []string{names[i], fmt.Sprintf("%d", sorted[i].Runners), record, date}
+	})
+}
+
+// displayStaleRecords lists the categories whose records are oldest.
+func displayStaleRecords(categories []CategoryStats, count int) {
+	var records []CategoryStats
+	for _, summary := range categories {
+		if summary.Record != nil && summary.Record.Date != "" {
+			records = append(records, summary)
+		}
+	}
+	if len(records) == 0 {
+		fmt.Println("No records yet.")
+		return
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Record.Date < records[j].Record.Date })
+	if count >= 0 && len(records) > count {
+		records = records[:count]
+	}
+	
+	names := make([]string, len(records))
+	holders := make([]string, len(records))
+	for i, summary := range records {
+		names[i] = summary.Name()
+		holders[i] = getPlayerDisplayName(*summary.Record)
+	}
+	columns := []TableColumn{
+		textColumn("Category", names, CategoryColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "WR", Width: TimeColumnWidth},
+		textColumn("Holder", holders, PlayerColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Date", Width: DateColumnWidth},
+		{Title: "Stood for", Width: 10, DropRank: 1},
+	}
+	printStatsTable(columns, len(records), func(i int) []string {
+		record := records[i].Record
+		return []string{names[i], getBestTime(*record), holders[i], record.Date, recordAge(record.Date)}
+	})
+}
+
+// printStatsTable fits the columns to the terminal and prints n rows.
+func printStatsTable(columns []TableColumn, n int, row func(i int) []string) {
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	for i := 0; i < n; i++ {
+		fmt.Println(formatTableRow(columns, row(i)))
+	}
+}
+
+// recordAge says how long a record set on date has stood, in years and
+// months, or days when under a month.
+func recordAge(date string) string {
+	set, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return EmptyValuePlaceholder
+	}
+	days := int(time.Since(set).Hours() / 24)
+	months := days * 12 / 365
+	switch {
+	case months == 0:
+		return fmt.Sprintf("%dd", days)
+	case months < 12:
+		return fmt.Sprintf("%dmo", months)
+	case months%12 == 0:
+		return fmt.Sprintf("%dy", months/12)
+	default:
+		return fmt.Sprintf("%dy %dmo", months/12, months%12)
+	}
+}
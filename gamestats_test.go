@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestLoadGameStats aggregates sm64 on the fake server. Every category is
+// split by the Version subcategory, so each category has a JP and a US
+// board. A co-op run by cheese and the guest Toad takes Glitchslayer's
+// 0 Star (US) record, and "toad" sets the only 70 Star (US) run; guests are
+// one runner whatever the case of their name, named as on the first board.
+func TestLoadGameStats(t *testing.T) {
+	api, server := startFakeAPI(t, fakeFaults{})
+	plantRun(t, server, "r00091", fakeObject{"id": "rcoop001", "date": "2026-09-01", "times": plantedTimes("PT6M"),
+		"players": []interface{}{fakeObject{"rel": "user", "id": "u000"}, fakeObject{"rel": "guest", "name": "Toad"}}})
+	plantRun(t, server, "r00058", fakeObject{"id": "rguest01", "date": "2026-09-02", "values": fakeObject{"e8m7em86": "jq6540ol"},
+		"players": []interface{}{fakeObject{"rel": "guest", "name": "toad"}}})
+	
+	game, err := api.GetGame("sm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := loadGameStats(api, game, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	// The stage category is per-level and left out; verified runs include
+	// obsolete and level runs, board runs only each runner's best
+	if stats.Categories != 4 || stats.Runs != 114 || stats.RunsCapped || stats.BoardRuns != 93 || stats.Runners != 41 {
+		t.Errorf("categories %d, runs %d (capped %v), board runs %d, runners %d; want 4, 114, 93, 41",
+			stats.Categories, stats.Runs, stats.RunsCapped, stats.BoardRuns, stats.Runners)
+	}
+	
+	var boards []string
+	for _, board := range stats.Boards {
+		record := "none"
+		if board.Record != nil {
+			record = board.Record.ID
+		}
+		boards = append(boards, fmt.Sprintf("%s: %d runners, WR %s", board.Name(), board.Runners, record))
+	}
+	wantBoards := []string{
+		"120 Star (JP): 40 runners, WR r00001",
+		"120 Star (US): 12 runners, WR r00046",
+		"70 Star (JP): 18 runners, WR r00058",
+		"70 Star (US): 1 runners, WR rguest01",
+		"16 Star (JP): 15 runners, WR r00076",
+		"16 Star (US): 0 runners, WR none",
+		"0 Star (JP): 0 runners, WR none",
+		"0 Star (US): 7 runners, WR rcoop001",
+	}
+	if !reflect.DeepEqual(boards, wantBoards) {
+		t.Errorf("boards =\n%q\nwant\n%q", boards, wantBoards)
+	}
+	
+	// Ranked by records, then podiums, then boards; the co-op record counts
+	// for both runners
+	wantRunners := []RunnerStats{
+		{Name: "cheese", WRs: 3, Podiums: 3, Boards: 3},
+		{Name: "toad", WRs: 2, Podiums: 2, Boards: 2},
+		{Name: "Weegee", WRs: 1, Podiums: 2, Boards: 2},
+		{Name: "KANNO", WRs: 1, Podiums: 1, Boards: 3},
+		{Name: "ikori_o", WRs: 0, Podiums: 2, Boards: 4},
+	}
+	if !reflect.DeepEqual(stats.TopRunners, wantRunners) {
+		t.Errorf("top runners =\n%+v\nwant\n%+v", stats.TopRunners, wantRunners)
+	}
+	
+	wantPlatforms := []PlatformShare{{"Nintendo 64", 54}, {"Wii Virtual Console", 27}, {"Wii U Virtual Console", 12}}
+	if !reflect.DeepEqual(stats.Platforms, wantPlatforms) {
+		t.Errorf("platforms = %+v, want %+v", stats.Platforms, wantPlatforms)
+	}
+}
+
+func TestLoadGameStatsWithoutBoards(t *testing.T) {
+	api, server := startFakeAPI(t, fakeFaults{})
+	var perLevel []fakeObject
+	for _, category := range server.data.Categories {
+		if category["type"] == "per-level" {
+			perLevel = append(perLevel, category)
+		}
+	}
+	server.data.Categories = perLevel
+	
+	game, err := api.GetGame("sm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadGameStats(api, game, 5); err == nil {
+		t.Error("loadGameStats() succeeded for a game without full-game categories")
+	}
+}
+
+func TestRecordAge(t *testing.T) {
+	daysAgo := func(days int) string {
+		return time.Now().UTC().AddDate(0, 0, -days).Format("2006-01-02")
+	}
+	
+	tests := []struct {
+		date string
+		want string
+	}{
+		{date: daysAgo(0), want: "0d"},
+		{date: daysAgo(10), want: "10d"},
+		{date: daysAgo(45), want: "1mo"},
+		{date: daysAgo(200), want: "6mo"},
+		{date: daysAgo(400), want: "1y 1mo"},
+		{date: daysAgo(731), want: "2y"},
+		{date: "", want: EmptyValuePlaceholder},
+		{date: "2020-13-01", want: EmptyValuePlaceholder},
+	}
+	
+	for _, tt := range tests {
+		if got := recordAge(tt.date); got != tt.want {
+			t.Errorf("recordAge(%q) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
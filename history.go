@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	HistoryFileName     = "history"
+	RecentGamesFileName = "recent_games.json"
+	MaxHistoryEntries   = 1000
+	MaxRecentGames      = 50
+)
+
+// History is the REPL input history, persisted across sessions. Inputs that
+// are only menu picks (numbers, single keys) are not recorded.
+type History struct {
+	path    string
+	entries []string
+}
+
+// SavedGame identifies a game in recent-game and bookmark lists.
+type SavedGame struct {
+	ID           string `json:"id"`
+	Abbreviation string `json:"abbreviation"`
+	Name         string `json:"name"`
+}
+
+func newSavedGame(game *Game) SavedGame {
+	return SavedGame{ID: game.ID, Abbreviation: game.Abbreviation, Name: game.Names.International}
+}
+
+// stateDir returns $XDG_STATE_HOME/speedrun-cli, falling back to
+// ~/.local/state/speedrun-cli.
+func stateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, ConfigDirName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".local", "state", ConfigDirName)
+	}
+	return filepath.Join(home, ".local", "state", ConfigDirName)
+}
+
+func loadHistory() *History {
+	h := &History{path: filepath.Join(stateDir(), HistoryFileName)}
+	
+	file, err := os.Open(h.path)
+	if err != nil {
+		return h
+	}
+	defer file.Close()
+	
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if len(h.entries) > MaxHistoryEntries {
+		h.entries = h.entries[len(h.entries)-MaxHistoryEntries:]
+	}
+	return h
+}
+
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+func (h *History) At(i int) string {
+	return h.entries[i]
+}
+
+// Add records an entry and appends it to the history file. Errors writing
+// the file are ignored; history is a convenience.
+func (h *History) Add(line string) {
+	line = strings.TrimSpace(line)
+	if !worthRemembering(line) {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == line {
+		return
+	}
+	
+	h.entries = append(h.entries, line)
+	if len(h.entries) > MaxHistoryEntries*2 {
+		h.entries = h.entries[len(h.entries)-MaxHistoryEntries:]
+		h.rewrite()
+		return
+	}
+	
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.WriteString(line + "\n")
+}
+
+func (h *History) rewrite() {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return
+	}
+	os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600)
+}
+
+// Search returns the index of the newest entry at or before from that
+// contains query (case-insensitive), or -1.
+func (h *History) Search(query string, from int) int {
+	query = strings.ToLower(query)
+	for i := from; i >= 0 && i < len(h.entries); i-- {
+		if strings.Contains(strings.ToLower(h.entries[i]), query) {
+			return i
+		}
+	}
+	return -1
+}
+
+func worthRemembering(line string) bool {
+	if len([]rune(line)) < 2 {
+		return false
+	}
+	if _, err := strconv.Atoi(line); err == nil {
+		return false
+	}
+	return true
+}
+
+// RecentGames returns recently opened games, newest first.
+func loadRecentGames() []SavedGame {
+	var games []SavedGame
+	data, err := os.ReadFile(filepath.Join(stateDir(), RecentGamesFileName))
+	if err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &games); err != nil {
+		return nil
+	}
+	return games
+}
+
+// rememberGame moves game to the front of the recent-games list.
+func rememberGame(game *Game) {
+	games := []SavedGame{newSavedGame(game)}
+	for _, existing := range loadRecentGames() {
+		if existing.ID != game.ID && len(games) < MaxRecentGames {
+			games = append(games, existing)
+		}
+	}
+	
+	data, err := json.MarshalIndent(games, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(stateDir(), 0700); err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(stateDir(), RecentGamesFileName), data, 0600)
+}
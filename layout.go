@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strings"
+)
+
+// Column widths for the terminal tables. Text columns size to their
+// content up to these caps and shrink toward MinTextColumnWidth on narrow
+// terminals.
+const (
+	RankColumnWidth             = 5
+	TimeColumnWidth             = 15
+	DateColumnWidth             = 10
+	StatusColumnWidth           = 8
+	VideoColumnWidth            = 5
+	EmuColumnWidth              = 3
+	AgeColumnWidth              = 8
+	YearColumnWidth             = 4
+	RunnersColumnWidth          = 7
+	PlacementColumnWidth        = 11
+	CategoryTypeColumnWidth     = 9
+	PlayerColumnMaxWidth        = 25
+	PlatformColumnMaxWidth      = 20
+	GameColumnMaxWidth          = 25
+	CategoryColumnMaxWidth      = 20
+	LevelCategoryColumnMaxWidth = 35
+	CommentColumnMaxWidth       = 30
+	MinTextColumnWidth          = 8
+	MinCommentColumnWidth       = 10
+)
+
+// TableColumn describes one column of a terminal table. Columns with a
+// MinWidth are truncated to fit; columns with a DropRank are hidden on
+// narrow terminals, highest rank first.
+type TableColumn struct {
+	Title    string
+	Width    int
+	MinWidth int
+	DropRank int
+	Hidden   bool
+}
+
+// textColumn sizes a truncatable column to its widest value, capped at maxWidth.
+func textColumn(title string, values []string, maxWidth, minWidth, dropRank int) TableColumn {
+	width := max(calculateDynamicWidth(values, maxWidth), displayWidth(title))
+	return TableColumn{Title: title, Width: width, MinWidth: minWidth, DropRank: dropRank}
+}
+
+func tableWidth(columns []TableColumn) int {
+	width := 0
+	visible := 0
+	for _, column := range columns {
+		if !column.Hidden {
+			width += column.Width
+			visible++
+		}
+	}
+	if visible > 1 {
+		width += visible - 1
+	}
+	return width
+}
+
+// fitColumns makes the table fit in maxWidth cells: droppable columns
+// shrink and then disappear, lowest priority first, before the remaining
+// text columns are narrowed, widest first. A maxWidth of zero or less
+// leaves the columns untouched.
+func fitColumns(columns []TableColumn, maxWidth int) {
+	if maxWidth <= 0 {
+		return
+	}
+	
+	for i := range columns {
+		if excess := tableWidth(columns) - maxWidth; excess > 0 && columns[i].DropRank > 0 && columns[i].MinWidth > 0 {
+			columns[i].Width = max(columns[i].MinWidth, columns[i].Width-excess)
+		}
+	}
+	
+	for tableWidth(columns) > maxWidth {
+		drop := -1
+		for i, column := range columns {
+			if !column.Hidden && column.DropRank > 0 && (drop < 0 || column.DropRank > columns[drop].DropRank) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		columns[drop].Hidden = true
+	}
+	
+	for tableWidth(columns) > maxWidth {
+		widest := -1
+		for i, column := range columns {
+			if !column.Hidden && column.MinWidth > 0 && column.Width > column.MinWidth &&
+				(widest < 0 || column.Width > columns[widest].Width) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		columns[widest].Width--
+	}
+}
+
+// formatTableRow lays out values under columns, truncating text columns and
+// padding by display width so emoji and wide characters stay aligned.
+func formatTableRow(columns []TableColumn, values []string) string {
+	cells := make([]string, 0, len(columns))
+	last := -1
+	for i, column := range columns {
+		if !column.Hidden {
+			last = i
+		}
+	}
+	
+	for i, column := range columns {
+		if column.Hidden {
+			continue
+		}
+		
+		value := values[i]
+		if column.MinWidth > 0 {
+			value = truncateString(value, column.Width)
+		}
+		if i != last {
+			value = padRight(value, column.Width)
+		}
+		cells = append(cells, value)
+	}
+	
+	return strings.Join(cells, " ")
+}
+
+func tableHeader(columns []TableColumn) string {
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+	return formatTableRow(columns, titles)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFitColumns(t *testing.T) {
+	// Rank, Player, Time, Video, Comment: 79 cells wide with separators.
+	newColumns := func() []TableColumn {
+		return []TableColumn{
+			{Title: "Rank", Width: 5},
+			{Title: "Player", Width: 20, MinWidth: 8},
+			{Title: "Time", Width: 15},
+			{Title: "Video", Width: 5, DropRank: 1},
+			{Title: "Comment", Width: 30, MinWidth: 10, DropRank: 3},
+		}
+	}
+	
+	tests := []struct {
+		name       string
+		maxWidth   int
+		wantWidths []int
+		wantHidden []bool
+	}{
+		{name: "wide terminal", maxWidth: 100, wantWidths: []int{5, 20, 15, 5, 30}, wantHidden: []bool{false, false, false, false, false}},
+		{name: "exact fit", maxWidth: 79, wantWidths: []int{5, 20, 15, 5, 30}, wantHidden: []bool{false, false, false, false, false}},
+		{name: "comment shrinks", maxWidth: 60, wantWidths: []int{5, 20, 15, 5, 11}, wantHidden: []bool{false, false, false, false, false}},
+		{name: "droppable columns hide", maxWidth: 45, wantWidths: []int{5, 20, 15, 5, 10}, wantHidden: []bool{false, false, false, true, true}},
+		{name: "player narrows", maxWidth: 30, wantWidths: []int{5, 8, 15, 5, 10}, wantHidden: []bool{false, false, false, true, true}},
+		{name: "gives up at minimum widths", maxWidth: 10, wantWidths: []int{5, 8, 15, 5, 10}, wantHidden: []bool{false, false, false, true, true}},
+		{name: "unknown width", maxWidth: 0, wantWidths: []int{5, 20, 15, 5, 30}, wantHidden: []bool{false, false, false, false, false}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := newColumns()
+			fitColumns(columns, tt.maxWidth)
+			
+			widths := make([]int, len(columns))
+			hidden := make([]bool, len(columns))
+			for i, column := range columns {
+				widths[i], hidden[i] = column.Width, column.Hidden
+			}
+			if !reflect.DeepEqual(widths, tt.wantWidths) {
+				t.Errorf("widths = %v, want %v", widths, tt.wantWidths)
+			}
+			if !reflect.DeepEqual(hidden, tt.wantHidden) {
+				t.Errorf("hidden = %v, want %v", hidden, tt.wantHidden)
+			}
+		})
+	}
+}
+
+func TestFormatTableRow(t *testing.T) {
+	columns := []TableColumn{
+		{Title: "Rank", Width: 5},
+		{Title: "Player", Width: 8, MinWidth: 8},
+		{Title: "Video", Width: 5, Hidden: true},
+		{Title: "Time", Width: 7},
+	}
+	
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{values: []string{"1", "cheese", "✅", "1:38:50"}, want: "1     cheese   1:38:50"},
+		{values: []string{"🥇", "日本語の名前", "✅", "1:38:50"}, want: "🥇    日本...  1:38:50"},
+		{values: []string{"23", "", "", "—"}, want: "23    —        —"},
+	}
+	
+	for _, tt := range tests {
+		got := formatTableRow(columns, tt.values)
+		if got != tt.want {
+			t.Errorf("formatTableRow(%q) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+	if got, want := tableHeader(columns), "Rank  Player   Time"; got != want {
+		t.Errorf("tableHeader() = %q, want %q", got, want)
+	}
+}
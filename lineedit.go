@@ -0,0 +1,599 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Keys decoded from escape sequences, outside the Unicode range.
+const (
+	keyUp rune = unicode.MaxRune + 1 + iota
+	keyDown
+	keyRight
+	keyLeft
+	keyHome
+	keyEnd
+	keyDelete
+	keyUnknown
+)
+
+var (
+	errInterrupted     = errors.New("interrupted")
+	errEchoUnsupported = errors.New("this terminal cannot hide typed input")
+)
+
+// LineEditor reads lines from stdin. On a terminal it offers
+// readline-style editing: cursor movement, history (Up/Down, Ctrl-P/N),
+// reverse search (Ctrl-R), and tab completion. When stdin is not a
+// terminal, TERM is "dumb", or raw mode is unavailable, it reads plain
+// lines.
+type LineEditor struct {
+	reader   *bufio.Reader
+	history  *History
+	complete func(prefix string) []string
+	
+	// Editing state for the line being read
+	prompt    string
+	buf       []rune
+	pos       int
+	cursorRow int
+}
+
+var lineEditor = &LineEditor{
+	reader:   bufio.NewReader(os.Stdin),
+	history:  loadHistory(),
+	complete: completeInput,
+}
+
+// ReadLine prints prompt and returns the entered line. It returns io.EOF
+// when input ends (Ctrl-D on an empty line) and errInterrupted on Ctrl-C.
+// Earlier commands can be recalled, but the answer is not recorded.
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+	return e.readLine(prompt, true, false)
+}
+
+// ReadCommand reads a line like ReadLine and records it in history. Only
+// the main command prompt uses it, so answers to questions such as
+// rejection reasons stay out of the history file.
+func (e *LineEditor) ReadCommand(prompt string) (string, error) {
+	return e.readLine(prompt, true, true)
+}
+
+// ReadSecret reads a line without echoing it or recording it in history.
+// It returns errEchoUnsupported rather than show the secret on a terminal
+// where echo cannot be turned off.
+func (e *LineEditor) ReadSecret(prompt string) (string, error) {
+	return e.readLine(prompt, false, false)
+}
+
+func (e *LineEditor) readLine(prompt string, echo, record bool) (string, error) {
+	// Piped input is never recorded; it is a script, not a session.
+	if !stdinIsTerminal() {
+		return e.readPlainLine(prompt, false)
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return e.readPlainInput(prompt, echo, record)
+	}
+	
+	restore, err := enableRawMode(os.Stdin.Fd())
+	if err != nil {
+		return e.readPlainInput(prompt, echo, record)
+	}
+	defer restore()
+	
+	// Print any leading lines of the prompt once; only the last line is
+	// redrawn while editing.
+	if i := strings.LastIndex(prompt, "\n"); i >= 0 {
+		fmt.Print(strings.ReplaceAll(prompt[:i+1], "\n", "\r\n"))
+		prompt = prompt[i+1:]
+	}
+	
+	if !echo {
+		fmt.Print(prompt)
+		line, err := e.readSecretRaw()
+		fmt.Print("\r\n")
+		return line, err
+	}
+	
+	line, err := e.edit(prompt)
+	if err == nil && record {
+		e.history.Add(line)
+	}
+	return line, err
+}
+
+// readPlainInput reads a line from a terminal without the editor, for
+// TERM=dumb or when raw mode is unavailable. Secrets are read with the
+// terminal's echo turned off.
+func (e *LineEditor) readPlainInput(prompt string, echo, record bool) (string, error) {
+	if echo {
+		return e.readPlainLine(prompt, record)
+	}
+	
+	restore, err := disableEcho(os.Stdin.Fd())
+	if err != nil {
+		return "", errEchoUnsupported
+	}
+	defer restore()
+	
+	line, err := e.readPlainLine(prompt, false)
+	fmt.Println()
+	return line, err
+}
+
+func (e *LineEditor) readPlainLine(prompt string, record bool) (string, error) {
+	fmt.Print(prompt)
+	line, err := e.reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	
+	line = strings.TrimRight(line, "\r\n")
+	if record {
+		e.history.Add(line)
+	}
+	return line, nil
+}
+
+func (e *LineEditor) readSecretRaw() (string, error) {
+	var buf []rune
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case keyEnter, '\n':
+			return string(buf), nil
+		case keyCtrlC:
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(buf) == 0 {
+				return "", io.EOF
+			}
+		case keyBackspace, keyCtrlH:
+			if len(buf) > 0 {
+				buf = buf[:len(buf)-1]
+			}
+		default:
+			if unicode.IsPrint(r) {
+				buf = append(buf, r)
+			}
+		}
+	}
+}
+
+// edit runs the interactive editing loop for one line.
+func (e *LineEditor) edit(prompt string) (string, error) {
+	e.prompt = prompt
+	e.buf = e.buf[:0]
+	e.pos = 0
+	e.cursorRow = 0
+	
+	historyIndex := e.history.Len()
+	draft := ""
+	lastWasTab := false
+	
+	e.refresh()
+	for {
+		key, err := e.readKey()
+		if err != nil {
+			return "", err
+		}
+		
+		if key != keyTab {
+			lastWasTab = false
+		}
+		
+		switch key {
+		case keyEnter, '\n':
+			e.pos = len(e.buf)
+			e.refresh()
+			fmt.Print("\r\n")
+			return string(e.buf), nil
+		case keyCtrlC:
+			fmt.Print("^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(e.buf) == 0 {
+				fmt.Print("\r\n")
+				return "", io.EOF
+			}
+			e.deleteAt(e.pos)
+		case keyBackspace, keyCtrlH:
+			if e.pos > 0 {
+				e.pos--
+				e.deleteAt(e.pos)
+			}
+		case keyDelete:
+			e.deleteAt(e.pos)
+		case keyLeft, keyCtrlB:
+			if e.pos > 0 {
+				e.pos--
+			}
+		case keyRight, keyCtrlF:
+			if e.pos < len(e.buf) {
+				e.pos++
+			}
+		case keyHome, keyCtrlA:
+			e.pos = 0
+		case keyEnd, keyCtrlE:
+			e.pos = len(e.buf)
+		case keyCtrlK:
+			e.buf = e.buf[:e.pos]
+		case keyCtrlU:
+			e.buf = append(e.buf[:0], e.buf[e.pos:]...)
+			e.pos = 0
+		case keyCtrlW:
+			start := e.pos
+			for start > 0 && e.buf[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && e.buf[start-1] != ' ' {
+				start--
+			}
+			e.buf = append(e.buf[:start], e.buf[e.pos:]...)
+			e.pos = start
+		case keyCtrlL:
+			fmt.Print("\x1b[H\x1b[2J")
+			e.cursorRow = 0
+		case keyUp, keyCtrlP:
+			if historyIndex == e.history.Len() {
+				draft = string(e.buf)
+			}
+			if historyIndex > 0 {
+				historyIndex--
+				e.setLine(e.history.At(historyIndex))
+			}
+		case keyDown, keyCtrlN:
+			if historyIndex < e.history.Len() {
+				historyIndex++
+				if historyIndex == e.history.Len() {
+					e.setLine(draft)
+				} else {
+					e.setLine(e.history.At(historyIndex))
+				}
+			}
+		case keyCtrlR:
+			line, accepted, err := e.reverseSearch()
+			if err != nil {
+				return "", err
+			}
+			e.setLine(line)
+			historyIndex = e.history.Len()
+			if accepted {
+				e.refresh()
+				fmt.Print("\r\n")
+				return line, nil
+			}
+		case keyTab:
+			e.completeLine(lastWasTab)
+			lastWasTab = true
+		default:
+			if key < unicode.MaxRune && unicode.IsPrint(key) {
+				e.buf = append(e.buf, 0)
+				copy(e.buf[e.pos+1:], e.buf[e.pos:])
+				e.buf[e.pos] = key
+				e.pos++
+			}
+		}
+		
+		e.refresh()
+	}
+}
+
+func (e *LineEditor) deleteAt(pos int) {
+	if pos < len(e.buf) {
+		e.buf = append(e.buf[:pos], e.buf[pos+1:]...)
+	}
+}
+
+func (e *LineEditor) setLine(line string) {
+	e.buf = append(e.buf[:0], []rune(line)...)
+	e.pos = len(e.buf)
+}
+
+// readKey reads one keypress, decoding arrow, Home/End, and Delete
+// escape sequences.
+func (e *LineEditor) readKey() (rune, error) {
+	r, _, err := e.reader.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if r != keyEscape {
+		return r, nil
+	}
+	
+	// A lone Escape arrives by itself; sequences arrive in one read.
+	if e.reader.Buffered() == 0 {
+		return keyEscape, nil
+	}
+	
+	next, _, err := e.reader.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if next != '[' && next != 'O' {
+		return keyUnknown, nil
+	}
+	
+	var params []rune
+	for {
+		c, _, err := e.reader.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		if c >= 0x40 && c <= 0x7e {
+			switch {
+			case c == 'A':
+				return keyUp, nil
+			case c == 'B':
+				return keyDown, nil
+			case c == 'C':
+				return keyRight, nil
+			case c == 'D':
+				return keyLeft, nil
+			case c == 'H':
+				return keyHome, nil
+			case c == 'F':
+				return keyEnd, nil
+			case c == '~' && (string(params) == "1" || string(params) == "7"):
+				return keyHome, nil
+			case c == '~' && (string(params) == "4" || string(params) == "8"):
+				return keyEnd, nil
+			case c == '~' && string(params) == "3":
+				return keyDelete, nil
+			}
+			return keyUnknown, nil
+		}
+		params = append(params, c)
+	}
+}
+
+// refresh redraws the prompt and buffer, handling lines that wrap past
+// the terminal width, and leaves the cursor at e.pos.
+func (e *LineEditor) refresh() {
+	cols, _ := terminalDimensions()
+	promptWidth := displayWidth(e.prompt)
+	total := promptWidth + displayWidth(string(e.buf))
+	cursor := promptWidth + displayWidth(string(e.buf[:e.pos]))
+	
+	var out strings.Builder
+	if e.cursorRow > 0 {
+		fmt.Fprintf(&out, "\x1b[%dA", e.cursorRow)
+	}
+	out.WriteString("\r")
+	out.WriteString(e.prompt)
+	out.WriteString(string(e.buf))
+	out.WriteString("\x1b[J")
+	
+	// With the cursor at the end of a full row, force the wrap so the
+	// terminal's cursor matches our row count.
+	endRow := total / cols
+	if total > 0 && total%cols == 0 {
+		out.WriteString("\r\n")
+	}
+	
+	cursorRow := cursor / cols
+	if up := endRow - cursorRow; up > 0 {
+		fmt.Fprintf(&out, "\x1b[%dA", up)
+	}
+	out.WriteString("\r")
+	if col := cursor % cols; col > 0 {
+		fmt.Fprintf(&out, "\x1b[%dC", col)
+	}
+	
+	e.cursorRow = cursorRow
+	fmt.Print(out.String())
+}
+
+// reverseSearch implements Ctrl-R incremental search through history. It
+// returns the chosen line and whether Enter accepted it outright.
+func (e *LineEditor) reverseSearch() (string, bool, error) {
+	original := string(e.buf)
+	savedPrompt := e.prompt
+	defer func() { e.prompt = savedPrompt }()
+	
+	var query []rune
+	match := ""
+	from := e.history.Len()
+	
+	for {
+		label := "(reverse-i-search)"
+		if match == "" && len(query) > 0 {
+			label = "(failed reverse-i-search)"
+		}
+		e.prompt = fmt.Sprintf("%s`%s': ", label, string(query))
+		e.setLine(match)
+		e.refresh()
+		
+		key, err := e.readKey()
+		if err != nil {
+			return "", false, err
+		}
+		
+		switch key {
+		case keyEnter, '\n':
+			return match, true, nil
+		case keyCtrlC:
+			fmt.Print("^C\r\n")
+			return "", false, errInterrupted
+		case keyCtrlG, keyEscape:
+			return original, false, nil
+		case keyCtrlR:
+			if idx := e.history.Search(string(query), from-1); idx >= 0 {
+				from = idx
+				match = e.history.At(idx)
+			}
+			continue
+		case keyBackspace, keyCtrlH:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+			}
+		default:
+			if key < unicode.MaxRune && unicode.IsPrint(key) {
+				query = append(query, key)
+			} else {
+				// Any other key accepts the match for further editing
+				return match, false, nil
+			}
+		}
+		
+		from = e.history.Len()
+		match = ""
+		if idx := e.history.Search(string(query), from-1); idx >= 0 && len(query) > 0 {
+			from = idx
+			match = e.history.At(idx)
+		}
+	}
+}
+
+// completeLine completes the text before the cursor. A unique candidate is
+// inserted; otherwise the common prefix is, and a second Tab lists the
+// candidates.
+func (e *LineEditor) completeLine(listCandidates bool) {
+	if e.complete == nil {
+		return
+	}
+	
+	prefix := string(e.buf[:e.pos])
+	candidates := e.complete(prefix)
+	if len(candidates) == 0 {
+		fmt.Print("\a")
+		return
+	}
+	
+	replacement := candidates[0]
+	if len(candidates) > 1 {
+		replacement = commonPrefix(candidates)
+	}
+	
+	if len([]rune(replacement)) > len([]rune(prefix)) {
+		rest := e.buf[e.pos:]
+		e.buf = append([]rune(replacement), rest...)
+		e.pos = len([]rune(replacement))
+		return
+	}
+	
+	if len(candidates) == 1 {
+		return
+	}
+	if !listCandidates {
+		fmt.Print("\a")
+		return
+	}
+	
+	e.pos = len(e.buf)
+	e.refresh()
+	fmt.Print("\r\n" + strings.Join(candidates, "   ") + "\r\n")
+	e.cursorRow = 0
+}
+
+func commonPrefix(values []string) string {
+	prefix := []rune(values[0])
+	for _, value := range values[1:] {
+		runes := []rune(value)
+		n := 0
+		for n < len(prefix) && n < len(runes) && unicode.ToLower(prefix[n]) == unicode.ToLower(runes[n]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// completeInput offers completions for the REPL: navigation commands,
+// leaderboard filters, and the names and abbreviations of recently opened
+// and bookmarked games.
+func completeInput(prefix string) []string {
+	lower := strings.ToLower(prefix)
+	
+	if strings.HasPrefix(lower, "queue ") {
+		var matches []string
+		for _, game := range gameCompletions(strings.TrimSpace(prefix[len("queue "):])) {
+			matches = append(matches, "queue "+game)
+		}
+		return matches
+	}
+	
+	var matches []string
+	seen := make(map[string]bool)
+	add := func(candidate string) {
+		if !seen[candidate] && strings.HasPrefix(strings.ToLower(candidate), lower) {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+	
+	if prefix != "" {
+		for _, command := range replCommands {
+			add(command)
+		}
+	}
+	for _, game := range gameCompletions(prefix) {
+		add(game)
+	}
+	return matches
+}
+
+// replCommands are the commands offered by tab completion.
+var replCommands = []string{
+	"back", "bookmarks", "category", "clear", "help", "inbox", "next",
+	"notifications", "prev", "queue ", "quit", "refresh", "rules", "series",
+	"user", "p1", "p2", "p3", "platform:", "runner:", "date>", "date<",
+	"video", "!video", "emu", "!emu",
+}
+
+// gameCompletions returns bookmarked and recently opened games whose name
+// or abbreviation starts with prefix.
+func gameCompletions(prefix string) []string {
+	lower := strings.ToLower(prefix)
+	var matches []string
+	seen := make(map[string]bool)
+	
+	games := append(loadBookmarks(), loadRecentGames()...)
+	for _, game := range games {
+		for _, candidate := range []string{game.Abbreviation, game.Name} {
+			if candidate != "" && !seen[candidate] && strings.HasPrefix(strings.ToLower(candidate), lower) {
+				seen[candidate] = true
+				matches = append(matches, candidate)
+			}
+		}
+	}
+	return matches
+}
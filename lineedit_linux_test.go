@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// openTerminal opens a pseudo-terminal and makes its slave end stdin for
+// the length of the test. Input typed at the terminal is written to the
+// returned master; what the terminal echoes can be read back from it.
+func openTerminal(t *testing.T) (master, slave *os.File) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminals: %v", err)
+	}
+	t.Cleanup(func() { master.Close() })
+	
+	var unlock int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		t.Skipf("unlocking pseudo-terminal: %v", errno)
+	}
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		t.Skipf("naming pseudo-terminal: %v", errno)
+	}
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("opening pseudo-terminal: %v", err)
+	}
+	t.Cleanup(func() { slave.Close() })
+	
+	savedStdin, savedEditor := os.Stdin, lineEditor
+	os.Stdin = slave
+	lineEditor = &LineEditor{
+		reader:  bufio.NewReader(slave),
+		history: &History{path: filepath.Join(t.TempDir(), HistoryFileName)},
+	}
+	t.Cleanup(func() { os.Stdin, lineEditor = savedStdin, savedEditor })
+	return master, slave
+}
+
+func echoing(terminal *os.File) (bool, error) {
+	var termios syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, terminal.Fd(), ioctlGetTermios, uintptr(unsafe.Pointer(&termios))); errno != 0 {
+		return false, errno
+	}
+	return termios.Lflag&syscall.ECHO != 0, nil
+}
+
+// readEcho reads what the terminal echoed up to and including marker.
+func readEcho(t *testing.T, master *os.File, marker string) string {
+	t.Helper()
+	var echoed strings.Builder
+	buf := make([]byte, 256)
+	for !strings.Contains(echoed.String(), marker) {
+		n, err := master.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		echoed.Write(buf[:n])
+	}
+	return echoed.String()
+}
+
+func TestDumbTerminalRecordsOnlyCommands(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	master, _ := openTerminal(t)
+	
+	lines := []struct {
+		input string
+		read  func(prompt string) (string, error)
+	}{
+		{input: "leaderboard sm64", read: lineEditor.ReadCommand},
+		{input: "missing the final star", read: lineEditor.ReadLine},
+		{input: "user cheese", read: lineEditor.ReadCommand},
+		{input: "nice run", read: lineEditor.ReadLine},
+	}
+	
+	captureStdout(t, func() {
+		for _, line := range lines {
+			if _, err := master.WriteString(line.input + "\n"); err != nil {
+				t.Fatal(err)
+			}
+			got, err := line.read("> ")
+			if err != nil || got != line.input {
+				t.Errorf("read %q, %v; want %q", got, err, line.input)
+			}
+		}
+	})
+	
+	want := []string{"leaderboard sm64", "user cheese"}
+	if got := lineEditor.history.entries; !reflect.DeepEqual(got, want) {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+	saved, err := os.ReadFile(lineEditor.history.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(saved); got != "leaderboard sm64\nuser cheese\n" {
+		t.Errorf("history file = %q", got)
+	}
+}
+
+func TestDumbTerminalHidesSecrets(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	master, slave := openTerminal(t)
+	
+	// Type the key once echo is off, as a person would after seeing the
+	// prompt. If echo never goes off, type it anyway so the test fails
+	// rather than hangs.
+	typed := make(chan error, 1)
+	go func() {
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+			on, err := echoing(slave)
+			if err != nil {
+				typed <- err
+				return
+			}
+			if !on {
+				break
+			}
+		}
+		_, err := master.WriteString("secret-api-key\n")
+		typed <- err
+	}()
+	
+	var key string
+	var err error
+	captureStdout(t, func() { key, err = lineEditor.ReadSecret("API key: ") })
+	if err != nil || key != "secret-api-key" {
+		t.Fatalf("ReadSecret() = %q, %v", key, err)
+	}
+	if err := <-typed; err != nil {
+		t.Fatal(err)
+	}
+	if on, err := echoing(slave); err != nil || !on {
+		t.Fatalf("echo was not restored (%v)", err)
+	}
+	
+	// Echo is back on, so the next line shows up; the key never did
+	if _, err := master.WriteString("shown\n"); err != nil {
+		t.Fatal(err)
+	}
+	if echoed := readEcho(t, master, "shown"); strings.Contains(echoed, "secret") {
+		t.Errorf("the key was echoed: %q", echoed)
+	}
+	if lineEditor.history.Len() != 0 {
+		t.Errorf("the key was recorded in history")
+	}
+}
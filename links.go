@@ -0,0 +1,307 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var abbreviationPattern = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// LinkTarget is what a speedrun.com URL points at.
+type LinkTarget struct {
+	Kind     string // "game", "category", "run", or "user"
+	Game     string // game ID or abbreviation
+	Category string // category ID, or the name fragment from the URL
+	Run      string
+	User     string
+}
+
+// parseSpeedrunURL recognises speedrun.com game, category, run, and user
+// URLs, with or without a scheme or "www.".
+func parseSpeedrunURL(input string) (LinkTarget, bool) {
+	input = strings.TrimSpace(input)
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+	
+	u, err := url.Parse(input)
+	if err != nil {
+		return LinkTarget{}, false
+	}
+	
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "speedrun.com" {
+		return LinkTarget{}, false
+	}
+	
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	
+	// Localised site paths start with a language code, e.g. /fr-FR/sm64
+	if len(segments) > 1 && len(segments[0]) == 5 && segments[0][2] == '-' {
+		segments = segments[1:]
+	}
+	
+	switch {
+	case len(segments) == 0:
+		return LinkTarget{}, false
+	case len(segments) >= 2 && (segments[0] == "user" || segments[0] == "users"):
+		return LinkTarget{Kind: "user", User: segments[1]}, true
+	case len(segments) >= 3 && (segments[1] == "run" || segments[1] == "runs"):
+		return LinkTarget{Kind: "run", Game: segments[0], Run: segments[2]}, true
+	}
+	
+	target := LinkTarget{Kind: "game", Game: segments[0]}
+	if categoryID := u.Query().Get("x"); categoryID != "" {
+		target.Kind = "category"
+		target.Category = categoryID
+	} else if u.Fragment != "" {
+		target.Kind = "category"
+		target.Category = u.Fragment
+	}
+	return target, true
+}
+
+// resolveInput interprets main-prompt input as a speedrun.com URL or a bare
+// game abbreviation. It reports false when the input should be searched.
+func resolveInput(api *SpeedrunAPI, nav *NavigationStack, input string) bool {
+	if target, ok := parseSpeedrunURL(input); ok {
+		if err := openLinkTarget(api, nav, target); err != nil {
+			fmt.Printf("Error opening %s: %v\n", input, err)
+		}
+		return true
+	}
+	
+	if !abbreviationPattern.MatchString(input) {
+		return false
+	}
+	
+	game, err := api.GetGame(input)
+	if err != nil || !strings.EqualFold(game.Abbreviation, input) {
+		return false
+	}
+	
+	fmt.Printf("Found exact match: %s\n", game.Names.International)
+	browseGame(api, nav, game)
+	return true
+}
+
+func openLinkTarget(api *SpeedrunAPI, nav *NavigationStack, target LinkTarget) error {
+	switch target.Kind {
+	case "user":
+		return openUser(api, target.User)
+	case "run":
+		return openRun(api, target.Run)
+	}
+	
+	game, err := api.GetGame(target.Game)
+	if err != nil {
+		return err
+	}
+	
+	if target.Kind == "game" {
+		browseGame(api, nav, game)
+		return nil
+	}
+	
+	categories, err := api.GetGameCategories(game.ID)
+	if err != nil {
+		return err
+	}
+	
+	category := matchCategoryFragment(categories, target.Category)
+	if category == nil {
+		fmt.Printf("Category %q not found, showing %s instead.\n", target.Category, game.Names.International)
+		browseGame(api, nav, game)
+		return nil
+	}
+	
+	fmt.Printf("\n%s%s - %s\n", icon("🎮"), game.Names.International, category.Name)
+	browseCategory(api, nav, game, category)
+	return nil
+}
+
+// matchCategoryFragment finds a category by ID, by the anchor of its
+// weblink, or by name with underscores standing in for spaces.
+func matchCategoryFragment(categories []Category, fragment string) *Category {
+	// Names like "Any%" are not valid escapes and are matched as typed
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		fragment = unescaped
+	}
+	name := strings.ReplaceAll(fragment, "_", " ")
+	
+	for i, category := range categories {
+		anchor := ""
+		if idx := strings.Index(category.Weblink, "#"); idx != -1 {
+			anchor = category.Weblink[idx+1:]
+		}
+		
+		if category.ID == fragment ||
+			(anchor != "" && strings.EqualFold(anchor, fragment)) ||
+			strings.EqualFold(category.Name, name) {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// resolveBoard finds a full-game leaderboard by game, category name, and
+// subcategory label or value ID. The subcategory is required when the
+// category has any and is nil otherwise.
+func resolveBoard(api *SpeedrunAPI, gameName, categoryName, subcategoryName string) (*Game, *Category, *SubCategory, error) {
+	game, err := api.GetGame(gameName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	
+	categories, err := api.GetGameCategories(game.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	category := matchCategoryFragment(categories, categoryName)
+	if category == nil {
+		return nil, nil, nil, fmt.Errorf("unknown category %q (available: %s)", categoryName, strings.Join(categoryNames(categories), ", "))
+	}
+	if category.Type != "per-game" {
+		return nil, nil, nil, fmt.Errorf("%s is a per-level category; only full-game leaderboards are supported", category.Name)
+	}
+	
+	subcategories, err := api.GetCategoryVariables(category.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sort.Slice(subcategories, func(i, j int) bool { return subcategories[i].Label < subcategories[j].Label })
+	for i, subcategory := range subcategories {
+		if subcategory.ID == subcategoryName || strings.EqualFold(subcategory.Label, subcategoryName) {
+			return game, category, &subcategories[i], nil
+		}
+	}
+	if subcategoryName == "" && len(subcategories) == 0 {
+		return game, category, nil, nil
+	}
+	
+	labels := make([]string, len(subcategories))
+	for i, subcategory := range subcategories {
+		labels[i] = subcategory.Label
+	}
+	if subcategoryName == "" {
+		return nil, nil, nil, fmt.Errorf("%s has subcategories; pick one with -subcategory (available: %s)", category.Name, strings.Join(labels, ", "))
+	}
+	return nil, nil, nil, fmt.Errorf("unknown subcategory %q (available: %s)", subcategoryName, strings.Join(labels, ", "))
+}
+
+func openRun(api *SpeedrunAPI, runID string) error {
+	run, err := api.GetRun(runID)
+	if err != nil {
+		return err
+	}
+	
+	game, err := api.GetGame(run.Game)
+	if err != nil {
+		return err
+	}
+	
+	fmt.Printf("\n%s%s\n", icon("🎮"), game.Names.International)
+	displayRunDetails(*run, loadRunNames(api, run.Game, []Run{*run}), QueuePlacement{})
+	return nil
+}
+
+func openUser(api *SpeedrunAPI, name string) error {
+	user, err := api.GetUser(name)
+	if err != nil {
+		return err
+	}
+	
+	if profile, err := loadUserProfile(api, user); err != nil {
+		fmt.Printf("Error loading profile: %v\n", err)
+	} else {
+		displayUserProfile(profile)
+	}
+	
+	runs, err := api.GetUserRuns(user.ID)
+	if err != nil {
+		return err
+	}
+	
+	displayUserRuns(user, runs)
+	return nil
+}
+
+func handleOpen(api *SpeedrunAPI, args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: speedrun-cli open <speedrun.com URL or game abbreviation>")
+		os.Exit(1)
+	}
+	
+	nav := NewNavigationStack()
+	input := strings.Join(args, " ")
+	
+	if target, ok := parseSpeedrunURL(input); ok {
+		if err := openLinkTarget(api, nav, target); err != nil {
+			fmt.Printf("Error opening %s: %v\n", input, err)
+			os.Exit(1)
+		}
+		return
+	}
+	
+	game, err := api.GetGame(input)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			fmt.Printf("No game, category, run, or user found for %q\n", input)
+		} else {
+			fmt.Printf("Error opening %s: %v\n", input, err)
+		}
+		os.Exit(1)
+	}
+	
+	browseGame(api, nav, game)
+}
+
+// handleLeaderboard opens a game's category leaderboard by game
+// abbreviation and category name, e.g. "leaderboard sm64 120_Star".
+// Without a category it opens the game. -date opens boards as they stood
+// on that day.
+func handleLeaderboard(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	date := fs.String("date", "", "show leaderboards as of YYYY-MM-DD")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli leaderboard [-date YYYY-MM-DD] <game> [category]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	
+	asOf, err := parseAsOfDate(*date)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	leaderboardAsOf = asOf
+	
+	args = fs.Args()
+	target := LinkTarget{Kind: "game", Game: args[0]}
+	if len(args) > 1 {
+		target.Kind = "category"
+		target.Category = strings.Join(args[1:], " ")
+	}
+	
+	if err := openLinkTarget(api, NewNavigationStack(), target); err != nil {
+		fmt.Printf("Error opening %s: %v\n", strings.Join(args, " "), err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSpeedrunURL(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   LinkTarget
+		wantOK bool
+	}{
+		{input: "https://www.speedrun.com/sm64", want: LinkTarget{Kind: "game", Game: "sm64"}, wantOK: true},
+		{input: "speedrun.com/sm64", want: LinkTarget{Kind: "game", Game: "sm64"}, wantOK: true},
+		{input: "https://www.speedrun.com/sm64#120_Star", want: LinkTarget{Kind: "category", Game: "sm64", Category: "120_Star"}, wantOK: true},
+		{input: "https://www.speedrun.com/sm64?x=wkpoo02r", want: LinkTarget{Kind: "category", Game: "sm64", Category: "wkpoo02r"}, wantOK: true},
+		{input: "https://www.speedrun.com/sm64/run/abc123", want: LinkTarget{Kind: "run", Game: "sm64", Run: "abc123"}, wantOK: true},
+		{input: "https://www.speedrun.com/sm64/runs/abc123", want: LinkTarget{Kind: "run", Game: "sm64", Run: "abc123"}, wantOK: true},
+		{input: "https://www.speedrun.com/user/cheese", want: LinkTarget{Kind: "user", User: "cheese"}, wantOK: true},
+		{input: "https://www.speedrun.com/users/cheese", want: LinkTarget{Kind: "user", User: "cheese"}, wantOK: true},
+		{input: "https://www.speedrun.com/fr-FR/sm64", want: LinkTarget{Kind: "game", Game: "sm64"}, wantOK: true},
+		{input: "https://www.speedrun.com/", wantOK: false},
+		{input: "https://example.com/sm64", wantOK: false},
+		{input: "sm64", wantOK: false},
+	}
+	
+	for _, tt := range tests {
+		got, ok := parseSpeedrunURL(tt.input)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseSpeedrunURL(%q) = %+v, %v, want %+v, %v", tt.input, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestMatchCategoryFragment(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	
+	tests := []struct {
+		game     string
+		fragment string
+		want     string // category name, "" for no match
+	}{
+		{game: "sm64", fragment: "120_Star", want: "120 Star"},
+		{game: "sm64", fragment: "120%20Star", want: "120 Star"},
+		{game: "sm64", fragment: "0_star", want: "0 Star"},
+		{game: "sm64", fragment: "wkpoo02r", want: "120 Star"},
+		{game: "sm64", fragment: "Stage_RTA", want: "Stage RTA"},
+		{game: "sm64", fragment: "Any%", want: ""},
+		{game: "celeste", fragment: "Any%", want: "Any%"},
+		{game: "celeste", fragment: "100%", want: "100%"},
+		{game: "celeste", fragment: "True_Ending", want: "True Ending"},
+		{game: "smo", fragment: "Darker_Side", want: "Darker Side"},
+	}
+	
+	for _, tt := range tests {
+		game, err := api.GetGame(tt.game)
+		if err != nil {
+			t.Fatal(err)
+		}
+		categories, err := api.GetGameCategories(game.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		
+		got := ""
+		if category := matchCategoryFragment(categories, tt.fragment); category != nil {
+			got = category.Name
+		}
+		if got != tt.want {
+			t.Errorf("%s#%s matched %q, want %q", tt.game, tt.fragment, got, tt.want)
+		}
+	}
+}
+
+// TestOpenLinkTarget opens run and user links against the fake server, the
+// kinds that show a view without further prompts.
+func TestOpenLinkTarget(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	plainOutput(t, 100, 0)
+	
+	tests := []struct {
+		url     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			url:  "https://www.speedrun.com/sm64/run/r00001",
+			want: []string{"Super Mario 64", "Run by cheese", "Category:    120 Star", "Time:        1:38:50", "https://www.speedrun.com/sm64/run/r00001"},
+		},
+		{
+			url:  "speedrun.com/user/Suigi",
+			want: []string{"👤 Suigi", "https://www.speedrun.com/user/Suigi", "Suigi - Recent Submitted Runs", "Stage RTA"},
+		},
+		{url: "https://www.speedrun.com/sm64/run/nosuchrun", wantErr: true},
+		{url: "https://www.speedrun.com/user/nobody-here", wantErr: true},
+	}
+	
+	for _, tt := range tests {
+		target, ok := parseSpeedrunURL(tt.url)
+		if !ok {
+			t.Fatalf("parseSpeedrunURL(%q) failed", tt.url)
+		}
+		
+		var err error
+		out := captureStdout(t, func() { err = openLinkTarget(api, NewNavigationStack(), target) })
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("opening %s succeeded, want an error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("opening %s: %v", tt.url, err)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("opening %s: output is missing %q:\n%s", tt.url, want, out)
+			}
+		}
+	}
+}
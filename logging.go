@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TraceBodyLimit caps how much of each request and response body --trace
+// records.
+const TraceBodyLimit = 2048
+
+var logLevels = map[string]slog.Level{
+	"error": slog.LevelError,
+	"warn":  slog.LevelWarn,
+	"info":  slog.LevelInfo,
+	"debug": slog.LevelDebug,
+}
+
+// logger receives all diagnostics. It discards everything until
+// configureLogging enables it, so the UI output stays clean by default.
+var logger = slog.New(slog.DiscardHandler)
+
+// configureLogging sets up logger from the log_level, log_file,
+// log_format, debug, and trace settings.
+func configureLogging() error {
+	levelName := settings.String("log_level")
+	if settings.Bool("debug") || settings.Bool("trace") {
+		levelName = "debug"
+	}
+	level, enabled := logLevels[levelName]
+	if !enabled {
+		logger = slog.New(slog.DiscardHandler)
+		return nil
+	}
+	
+	var out io.Writer = os.Stderr
+	if path := settings.String("log_file"); path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("opening log file: %w", err)
+		}
+		out = file
+	}
+	
+	options := &slog.HandlerOptions{Level: level}
+	if settings.String("log_format") == "json" {
+		logger = slog.New(slog.NewJSONHandler(out, options))
+	} else {
+		logger = slog.New(slog.NewTextHandler(out, options))
+	}
+	return nil
+}
+
+// logRequest records one API call attempt. cache is "hit", "miss", or
+// "off" for requests that are never cached.
+func logRequest(method, endpoint string, attempt, status, size int, latency time.Duration, cache string, err error) {
+	attrs := []any{
+		"method", method,
+		"endpoint", endpoint,
+		"attempt", attempt,
+		"status", status,
+		"latency", latency.Round(time.Millisecond),
+		"bytes", size,
+		"cache", cache,
+	}
+	if err != nil {
+		logger.Warn("api request failed", append(attrs, "error", err)...)
+		return
+	}
+	logger.Info("api request", attrs...)
+}
+
+// enableServerLogging turns on info-level logging for 'serve' and
+// 'fake-server', which log every request they answer, unless log_level
+// was set explicitly.
+func enableServerLogging() error {
+	if settings.Source("log_level") != "default" {
+		return nil
+	}
+	settings.values["log_level"] = "info"
+	return configureLogging()
+}
+
+// logAccess records one request answered by 'serve' or 'fake-server'.
+func logAccess(r *http.Request, status int, latency time.Duration) {
+	logger.Info("http request",
+		"method", r.Method,
+		"path", r.URL.RequestURI(),
+		"status", status,
+		"latency", latency.Round(time.Millisecond),
+		"remote", r.RemoteAddr)
+}
+
+// traceRequest dumps a request's headers and body under --trace. The API
+// key is never written.
+func traceRequest(req *http.Request, payload []byte) {
+	if !settings.Bool("trace") {
+		return
+	}
+	logger.Debug("trace request",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"headers", traceHeaders(req.Header),
+		"body", traceBody(payload))
+}
+
+// traceResponse dumps a response's status, headers, and body under --trace.
+func traceResponse(endpoint string, resp *http.Response, body []byte) {
+	if !settings.Bool("trace") {
+		return
+	}
+	logger.Debug("trace response",
+		"endpoint", endpoint,
+		"status", resp.Status,
+		"headers", traceHeaders(resp.Header),
+		"body", traceBody(body))
+}
+
+func traceHeaders(header http.Header) string {
+	var names []string
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	var parts []string
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if strings.EqualFold(name, "X-API-Key") {
+			value = maskSecret(value)
+		}
+		parts = append(parts, name+": "+value)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func traceBody(body []byte) string {
+	if len(body) > TraceBodyLimit {
+		return fmt.Sprintf("%s... (%d bytes)", body[:TraceBodyLimit], len(body))
+	}
+	return string(body)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 var (
@@ -12,6 +13,22 @@ var (
 )
 
 func main() {
+	args, err := parseGlobalFlags(os.Args)
+	if err != nil {
+		// Completion stays quiet on errors; the shell has nothing to show
+		if len(args) > 1 && args[1] == "__complete" {
+			os.Exit(1)
+		}
+		// 'config' still runs with a broken config file so it can be fixed
+		if len(args) < 2 || args[1] != "config" {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Warning: %v\n", err)
+	}
+	os.Args = args
+	watchTerminalResize()
+	
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
 		case "--version", "-v":
@@ -22,23 +39,91 @@ func main() {
 		case "--help", "-h":
 			showHelp()
 			return
+		case "queue":
+			if len(os.Args) < 3 {
+				fmt.Println("Usage: speedrun-cli queue <game>")
+				os.Exit(1)
+			}
+			handleQueue(NewSpeedrunAPI(), strings.Join(os.Args[2:], " "))
+			return
+		case "open":
+			handleOpen(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "leaderboard":
+			handleLeaderboard(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "diff":
+			handleDiff(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "compare":
+			handleCompare(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "profile":
+			handleProfile(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "game-stats":
+			handleGameStats(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "submit":
+			handleSubmit(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "notifications", "inbox":
+			handleNotifications(NewSpeedrunAPI())
+			return
+		case "config":
+			handleConfig(os.Args[2:])
+			return
+		case "completion":
+			handleCompletion(os.Args[2:])
+			return
+		case "feed":
+			handleFeed(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "overlay":
+			handleOverlay(NewSpeedrunAPI(), os.Args[2:])
+			return
+		case "serve":
+			handleServe(os.Args[2:])
+			return
+		case "sync":
+			handleSync(os.Args[2:])
+			return
+		case "fake-server":
+			handleFakeServer(os.Args[2:])
+			return
+		case "__complete":
+			handleComplete(os.Args[2:])
+			return
+		case "login":
+			handleLogin(NewSpeedrunAPI())
+			return
+		case "logout":
+			if err := removeAPIKey(); err != nil {
+				fmt.Printf("Error removing API key: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("Logged out.")
+			return
 		}
 	}
-
+	
 	api := NewSpeedrunAPI()
 	nav := NewNavigationStack()
 	
-	fmt.Printf("🏃 Speedrun.com CLI v%s - Game Leaderboard Browser\n", Version)
+	fmt.Printf("%sSpeedrun.com CLI v%s - Game Leaderboard Browser\n", icon("🏃"), Version)
 	fmt.Println("==============================================")
 	fmt.Println("Type 'h' or 'help' for instructions")
+	if api.offline != nil {
+		fmt.Printf("%sOffline: browsing %d synced games (see 'speedrun-cli sync -list')\n", icon("📦"), len(api.offline.snapshots))
+	}
 	
 	for {
-		query := getUserInput("\nEnter game name to search (or 'u' for user search, 'q' to quit): ")
+		query := getCommandInput(mainPrompt(api))
 		
 		choice := parseUserInput(query)
 		
 		if choice.IsQuit {
-			fmt.Println("Goodbye! 👋")
+			fmt.Println(goodbye())
 			break
 		}
 		
@@ -52,10 +137,41 @@ func main() {
 			continue
 		}
 		
+		if choice.IsSeries {
+			handleSeriesSearch(api, nav)
+			continue
+		}
+		
+		if choice.Command == "inbox" || choice.Command == "notifications" {
+			handleNotifications(api)
+			continue
+		}
+		
+		if choice.Command == "bookmarks" {
+			if bookmark := selectBookmark(); bookmark != nil {
+				game, err := api.GetGame(bookmark.ID)
+				if err != nil {
+					fmt.Printf("Error loading game: %v\n", err)
+					continue
+				}
+				browseGame(api, nav, game)
+			}
+			continue
+		}
+		
+		if strings.HasPrefix(choice.Command, "queue ") {
+			handleQueue(api, strings.TrimSpace(query[len("queue "):]))
+			continue
+		}
+		
 		if query == "" {
 			continue
 		}
 		
+		if resolveInput(api, nav, query) {
+			continue
+		}
+		
 		games, err := api.SearchGames(query)
 		if err != nil {
 			fmt.Printf("Error searching games: %v\n", err)
@@ -67,119 +183,412 @@ func main() {
 			continue
 		}
 		
-		nav.Push("game")
+		browseGame(api, nav, selectedGame)
+	}
+}
+
+// browseGame shows the game overview, then runs the category →
+// subcategory → leaderboard flow for the game.
+func browseGame(api *SpeedrunAPI, nav *NavigationStack, selectedGame *Game) {
+	rememberGame(selectedGame)
+	
+	overview, err := api.GetGameOverview(selectedGame.ID)
+	if err != nil {
+		fmt.Printf("Error loading game overview: %v\n", err)
+	} else {
+		displayGameOverview(overview)
+		showSnapshotAge(api, selectedGame.ID)
 		
-		for nav.Current() == "game" {
-			fmt.Printf("\n📋 Loading categories for %s...\n", selectedGame.Names.International)
-			categories, err := api.GetGameCategories(selectedGame.ID)
-			if err != nil {
-				fmt.Printf("Error loading categories: %v\n", err)
-				nav.Pop()
-				break
+		for {
+			input := getUserInput(fmt.Sprintf("\nPress Enter to browse categories, %s, 'b' to go back, 'q' to quit: ", bookmarkPrompt(selectedGame.ID)))
+			choice := parseUserInput(input)
+			
+			if choice.IsQuit {
+				fmt.Println(goodbye())
+				os.Exit(0)
 			}
 			
-			selectedCategory := selectCategory(categories)
-			if selectedCategory == nil {
-				nav.Pop()
+			if choice.IsBack {
+				return
+			}
+			
+			if choice.Command != "*" {
 				break
 			}
 			
-			if selectedCategory.ID == "BACK" {
+			bookmarked, err := toggleBookmark(selectedGame)
+			if err != nil {
+				fmt.Printf("Error saving bookmarks: %v\n", err)
+			} else if bookmarked {
+				fmt.Printf("%sBookmarked %s\n", icon("⭐"), selectedGame.Names.International)
+			} else {
+				fmt.Printf("Removed bookmark for %s\n", selectedGame.Names.International)
+			}
+		}
+	}
+	
+	nav.Push("game")
+	
+	for nav.Current() == "game" {
+		fmt.Printf("\n%sLoading categories for %s...\n", icon("📋"), selectedGame.Names.International)
+		categories, err := api.GetGameCategories(selectedGame.ID)
+		if err != nil {
+			fmt.Printf("Error loading categories: %v\n", err)
+			nav.Pop()
+			break
+		}
+		
+		selectedCategory := selectCategory(categories)
+		if selectedCategory == nil {
+			nav.Pop()
+			break
+		}
+		
+		if selectedCategory.ID == "BACK" {
+			nav.Pop()
+			break
+		}
+		
+		browseCategory(api, nav, selectedGame, selectedCategory)
+	}
+}
+
+// browseCategory runs the subcategory → leaderboard flow for a category.
+func browseCategory(api *SpeedrunAPI, nav *NavigationStack, selectedGame *Game, selectedCategory *Category) {
+	nav.Push("category")
+	asOf := leaderboardAsOf
+	
+	for nav.Current() == "category" {
+		fmt.Printf("\n%sLoading subcategories for %s - %s...\n", icon("🏷️"), selectedGame.Names.International, selectedCategory.Name)
+		subCategories, err := api.GetCategoryVariables(selectedCategory.ID)
+		if err != nil {
+			fmt.Printf("Error loading subcategories: %v\n", err)
+			nav.Pop()
+			break
+		}
+		
+		selectedSubCategory := selectSubCategory(selectedCategory, subCategories)
+		if selectedSubCategory == nil {
+			nav.Pop()
+			break
+		}
+		
+		if selectedSubCategory.ID == "BACK" {
+			nav.Pop()
+			break
+		}
+		
+		nav.Push("subcategory")
+		
+		for nav.Current() == "subcategory" {
+			fmt.Printf("\n%sLoading leaderboard for %s - %s (%s)...\n", icon("🏆"), 
+				selectedGame.Names.International, selectedCategory.Name, selectedSubCategory.Label)
+			
+			leaderboard, err := api.GetLeaderboard(selectedGame.ID, selectedCategory.ID, "", selectedSubCategory.ID, asOf)
+			if err != nil {
+				fmt.Printf("Error loading leaderboard: %v\n", err)
 				nav.Pop()
 				break
 			}
 			
-			nav.Push("category")
+			showSnapshotAge(api, selectedGame.ID)
 			
-			for nav.Current() == "category" {
-				fmt.Printf("\n🏷️  Loading subcategories for %s - %s...\n", selectedGame.Names.International, selectedCategory.Name)
-				subCategories, err := api.GetCategoryVariables(selectedCategory.ID)
-				if err != nil {
-					fmt.Printf("Error loading subcategories: %v\n", err)
-					nav.Pop()
-					break
+			view := newLeaderboardView(leaderboard)
+			if filter := settings.String("default_filter"); filter != "" {
+				if err := view.applyFilter(api, filter); err != nil {
+					fmt.Printf("Error applying default filter: %v\n", err)
+				}
+			}
+			currentPage := 1
+			totalPages := 1
+			refreshRequested := false
+			
+			pageSize := leaderboardPageSize()
+			
+			for {
+				// Keep the first visible run on screen when a resize changes the page size
+				if size := leaderboardPageSize(); size != pageSize {
+					currentPage = (currentPage-1)*pageSize/size + 1
+					pageSize = size
+				}
+				
+				totalPages = displayLeaderboard(view.shown, currentPage, view.highlight)
+				
+				choice := handleLeaderboardNavigation(currentPage, totalPages)
+				
+				if choice.IsQuit {
+					fmt.Println(goodbye())
+					os.Exit(0)
 				}
 				
-				selectedSubCategory := selectSubCategory(subCategories)
-				if selectedSubCategory == nil {
+				if choice.IsBack {
 					nav.Pop()
 					break
 				}
 				
-				if selectedSubCategory.ID == "BACK" {
+				if choice.IsCategory {
+					nav.Pop()
 					nav.Pop()
 					break
 				}
 				
-				nav.Push("subcategory")
+				if choice.IsRefresh {
+					api.InvalidateCache(fmt.Sprintf("/leaderboards/%s/category/%s", selectedGame.ID, selectedCategory.ID))
+					refreshRequested = true
+					break // Will reload the leaderboard
+				}
+				
+				if choice.IsHelp {
+					showHelp()
+					continue
+				}
+				
+				if choice.IsRules {
+					displayRules(selectedCategory.Name+" Rules",
+						categoryRulesSections(selectedCategory, []SubCategory{*selectedSubCategory}))
+					continue
+				}
+				
+				if choice.IsNext && currentPage < totalPages {
+					currentPage++
+					continue
+				}
+				
+				if choice.IsPrev && currentPage > 1 {
+					currentPage--
+					continue
+				}
+				
+				if choice.PageNum > 0 && choice.PageNum <= totalPages {
+					currentPage = choice.PageNum
+					continue
+				}
+				
+				if choice.Command == "d" || strings.HasPrefix(choice.Command, "d ") {
+					input := strings.TrimSpace(choice.Command[1:])
+					if input == "" {
+						input = getUserInput("Show the leaderboard as of (YYYY-MM-DD, Enter for today): ")
+					}
+					date, err := parseAsOfDate(input)
+					if err != nil {
+						fmt.Printf("Error: %v\n", err)
+						continue
+					}
+					asOf = date
+					refreshRequested = true
+					break
+				}
 				
-				for nav.Current() == "subcategory" {
-					fmt.Printf("\n🏆 Loading leaderboard for %s - %s (%s)...\n", 
-						selectedGame.Names.International, selectedCategory.Name, selectedSubCategory.Label)
-					
-					leaderboard, err := api.GetLeaderboard(selectedGame.ID, selectedCategory.ID, "", selectedSubCategory.ID)
+				if strings.HasPrefix(choice.Command, "diff ") {
+					date, err := parseAsOfDate(choice.Command[len("diff "):])
+					if err == nil && (date == "" || (asOf != "" && date >= asOf)) {
+						err = fmt.Errorf("compare with a date before %s", asOfLabel(asOf))
+					}
+					if err == nil {
+						title := fmt.Sprintf("%s - %s (%s)", selectedGame.Names.International, selectedCategory.Name, selectedSubCategory.Label)
+						err = showLeaderboardDiff(api, view.full, selectedGame.ID, selectedCategory.ID, selectedSubCategory.ID, title, date)
+					}
 					if err != nil {
-						fmt.Printf("Error loading leaderboard: %v\n", err)
-						nav.Pop()
-						break
+						fmt.Printf("Error: %v\n", err)
+						continue
 					}
-					
-					currentPage := 1
-					totalPages := 1
-					refreshRequested := false
-					
-					for {
-						totalPages = displayLeaderboard(leaderboard, currentPage)
-						
-						choice := handleLeaderboardNavigation(currentPage, totalPages)
-						
-						if choice.IsQuit {
-							fmt.Println("Goodbye! 👋")
-							os.Exit(0)
-						}
-						
-						if choice.IsBack {
-							nav.Pop()
-							break
-						}
-						
-						if choice.IsCategory {
-							nav.Pop()
-							nav.Pop()
-							break
-						}
-						
-						if choice.IsRefresh {
-							refreshRequested = true
-							break // Will reload the leaderboard
-						}
-						
-						if choice.IsHelp {
-							showHelp()
-							continue
-						}
-						
-						if choice.IsNext && currentPage < totalPages {
-							currentPage++
-							continue
-						}
-						
-						if choice.IsPrev && currentPage > 1 {
-							currentPage--
-							continue
-						}
-						
-						if choice.PageNum > 0 && choice.PageNum <= totalPages {
-							currentPage = choice.PageNum
-							continue
-						}
+					getUserInput("\nPress Enter to return to the leaderboard: ")
+					continue
+				}
+				
+				if strings.HasPrefix(choice.Command, "/") {
+					if page, found, err := view.search(api, strings.TrimSpace(choice.Command[1:])); err != nil {
+						fmt.Printf("Error loading runner names: %v\n", err)
+					} else if found {
+						currentPage = page
+					} else {
+						fmt.Println("No matching runner found.")
 					}
-					
-					if refreshRequested {
+					continue
+				}
+				
+				if choice.Command == "clear" {
+					view.clearFilters()
+					currentPage = 1
+					continue
+				}
+				
+				if choice.Command != "" {
+					if err := view.applyFilter(api, choice.Command); err != nil {
+						fmt.Printf("Error: %v\n", err)
 						continue
 					}
+					currentPage = 1
+					continue
 				}
 			}
+			
+			if refreshRequested {
+				continue
+			}
+		}
+	}
+}
+
+// mainPrompt builds the game search prompt, with an unread notification
+// badge when an API key is configured.
+func mainPrompt(api *SpeedrunAPI) string {
+	if !api.HasAPIKey() {
+		return "\nEnter game name to search (or 'u' for user search, 's' for series, 'q' to quit): "
+	}
+	
+	badge := ""
+	if unread := api.UnreadNotificationCount(); unread > 0 {
+		badge = fmt.Sprintf("%s%d unread | ", icon("🔔"), unread)
+	}
+	return fmt.Sprintf("\n%sEnter game name to search (or 'u' for user search, 's' for series, 'inbox' for notifications, 'q' to quit): ", badge)
+}
+
+func handleNotifications(api *SpeedrunAPI) {
+	if !api.HasAPIKey() {
+		fmt.Printf("%sSet %s or run 'speedrun-cli login' to see your notifications.\n", icon("ℹ️"), APIKeyEnvVar)
+		return
+	}
+	
+	for {
+		notifications, err := api.GetNotifications()
+		if err != nil {
+			fmt.Printf("Error loading notifications: %v\n", err)
+			return
+		}
+		api.setUnreadCount(countUnread(notifications))
+		
+		displayNotifications(notifications)
+		refreshRequested := false
+		
+		for {
+			input := getUserInput("\nEnter number for details, 'r' refresh, 'b' back, 'q' quit: ")
+			choice := parseUserInput(input)
+			
+			if choice.IsQuit {
+				fmt.Println(goodbye())
+				os.Exit(0)
+			}
+			
+			if choice.IsBack || input == "" {
+				return
+			}
+			
+			if choice.IsRefresh {
+				refreshRequested = true
+				break
+			}
+			
+			if choice.IsHelp {
+				showHelp()
+				continue
+			}
+			
+			if choice.Index >= 0 && choice.Index < len(notifications) {
+				displayNotificationDetails(notifications[choice.Index])
+				continue
+			}
+			
+			showInputError(len(notifications), true)
+		}
+		
+		if !refreshRequested {
+			return
+		}
+	}
+}
+
+func handleSeriesSearch(api *SpeedrunAPI, nav *NavigationStack) {
+	for {
+		seriesQuery := getUserInput("\nEnter series name to search (or 'b' to go back): ")
+		
+		choice := parseUserInput(seriesQuery)
+		
+		if choice.IsQuit {
+			fmt.Println(goodbye())
+			os.Exit(0)
+		}
+		
+		if choice.IsBack {
+			return
+		}
+		
+		if choice.IsHelp {
+			showHelp()
+			continue
+		}
+		
+		if seriesQuery == "" {
+			continue
+		}
+		
+		series, err := api.SearchSeries(seriesQuery)
+		if err != nil {
+			fmt.Printf("Error searching series: %v\n", err)
+			continue
+		}
+		
+		selectedSeries := selectSeries(series)
+		if selectedSeries == nil {
+			continue
+		}
+		
+		browseSeries(api, nav, selectedSeries)
+	}
+}
+
+// browseSeries shows the series overview and lets the user drill into any
+// of its games, returning to the overview afterwards.
+func browseSeries(api *SpeedrunAPI, nav *NavigationStack, series *Series) {
+	nav.Push("series")
+	
+	for nav.Current() == "series" {
+		fmt.Printf("\n%sLoading games for %s...\n", icon("🎮"), series.Names.International)
+		games, err := api.GetSeriesGames(series.ID)
+		if err != nil {
+			fmt.Printf("Error loading series games: %v\n", err)
+			nav.Pop()
+			break
+		}
+		
+		entries := api.GetSeriesOverview(games)
+		refreshRequested := false
+		
+		for {
+			displaySeriesOverview(series, entries)
+			
+			choice := getUserChoice("\nEnter number to open a game, 'r' refresh, 'b' back, 'q' quit: ", len(entries), true)
+			
+			if choice.IsQuit {
+				fmt.Println(goodbye())
+				os.Exit(0)
+			}
+			
+			if choice.IsBack || choice.IsCategory {
+				nav.Pop()
+				break
+			}
+			
+			if choice.IsRefresh {
+				api.InvalidateCache("/series/" + series.ID)
+				api.InvalidateCache("/leaderboards")
+				refreshRequested = true
+				break
+			}
+			
+			if choice.IsHelp {
+				showHelp()
+				continue
+			}
+			
+			if choice.Index >= 0 {
+				game := entries[choice.Index].Game
+				browseGame(api, nav, &game)
+			}
+		}
+		
+		if !refreshRequested {
+			break
 		}
 	}
 }
@@ -191,7 +600,7 @@ func handleUserSearch(api *SpeedrunAPI) {
 		choice := parseUserInput(userQuery)
 		
 		if choice.IsQuit {
-			fmt.Println("Goodbye! 👋")
+			fmt.Println(goodbye())
 			return
 		}
 		
@@ -219,20 +628,32 @@ func handleUserSearch(api *SpeedrunAPI) {
 			continue
 		}
 		
-		runs, err := api.GetUserRuns(selectedUser.ID)
-		if err != nil {
-			fmt.Printf("Error loading user runs: %v\n", err)
-			continue
+		// Without a profile, go straight to the runs
+		input := "runs"
+		if profile, err := loadUserProfile(api, selectedUser); err != nil {
+			fmt.Printf("Error loading profile: %v\n", err)
+		} else {
+			displayUserProfile(profile)
+			
+			fmt.Println("\nType 'runs' for recent runs, Enter to continue, 'b' to go back, 'q' to quit:")
+			input = getUserInput("")
+		}
+		if strings.EqualFold(strings.TrimSpace(input), "runs") {
+			runs, err := api.GetUserRuns(selectedUser.ID)
+			if err != nil {
+				fmt.Printf("Error loading user runs: %v\n", err)
+				continue
+			}
+			
+			displayUserRuns(selectedUser, runs)
+			
+			fmt.Println("\nPress Enter to continue, 'b' to go back, 'q' to quit:")
+			input = getUserInput("")
 		}
-		
-		displayUserRuns(selectedUser, runs)
-		
-		fmt.Println("\nPress Enter to continue, 'b' to go back, 'q' to quit:")
-		input := getUserInput("")
 		navChoice := parseUserInput(input)
 		
 		if navChoice.IsQuit {
-			fmt.Println("Goodbye! 👋")
+			fmt.Println(goodbye())
 			return
 		}
 		
@@ -242,4 +663,183 @@ func handleUserSearch(api *SpeedrunAPI) {
 		
 		return
 	}
-}
\ No newline at end of file
+}
+
+func handleQueue(api *SpeedrunAPI, gameQuery string) {
+	games, err := api.SearchGames(gameQuery)
+	if err != nil {
+		fmt.Printf("Error searching games: %v\n", err)
+		return
+	}
+	
+	selectedGame := selectGame(games)
+	if selectedGame == nil {
+		return
+	}
+	
+	for {
+		runs, err := api.GetGameQueue(selectedGame.ID)
+		if err != nil {
+			fmt.Printf("Error loading verification queue: %v\n", err)
+			return
+		}
+		
+		categoryNames := loadRunNames(api, selectedGame.ID, runs)
+		placements := api.ProjectQueuePlacements(runs)
+		refreshRequested := false
+		
+		displayQueue(selectedGame, runs, categoryNames, placements)
+		
+		for {
+			input := getUserInput("\nEnter run number for details, 'r' refresh, 'b' back, 'q' quit: ")
+			choice := parseUserInput(input)
+			
+			if choice.IsQuit {
+				fmt.Println(goodbye())
+				os.Exit(0)
+			}
+			
+			if choice.IsBack {
+				return
+			}
+			
+			if choice.IsRefresh {
+				refreshRequested = true
+				break
+			}
+			
+			if choice.IsHelp {
+				showHelp()
+				continue
+			}
+			
+			if choice.Index >= 0 && choice.Index < len(runs) {
+				run := runs[choice.Index]
+				displayRunDetails(run, categoryNames, placements[run.ID])
+				if handleRunModeration(api, run) {
+					refreshRequested = true
+					break
+				}
+				continue
+			}
+			
+			if input == "" {
+				displayQueue(selectedGame, runs, categoryNames, placements)
+				continue
+			}
+			
+			showInputError(len(runs), true)
+		}
+		
+		if !refreshRequested {
+			return
+		}
+	}
+}
+
+// handleRunModeration offers verify/reject actions for a queued run and
+// reports whether the run's status was changed.
+func handleRunModeration(api *SpeedrunAPI, run Run) bool {
+	if !api.HasAPIKey() {
+		fmt.Printf("\n%sSet %s or run 'speedrun-cli login' to verify or reject runs.\n", icon("ℹ️"), APIKeyEnvVar)
+		return false
+	}
+	
+	for {
+		input := getUserInput("\n'v' verify, 'x' reject, Enter to return to the queue: ")
+		choice := parseUserInput(input)
+		
+		if input == "" || choice.IsBack {
+			return false
+		}
+		
+		if choice.IsQuit {
+			fmt.Println(goodbye())
+			os.Exit(0)
+		}
+		
+		switch choice.Command {
+		case "v", "verify":
+			if !confirm(fmt.Sprintf("Verify %s's run in %s? [y/N]: ", getPlayerDisplayName(run), getBestTime(run))) {
+				fmt.Println("Cancelled.")
+				continue
+			}
+			if err := api.SetRunStatus(run.ID, "verified", ""); err != nil {
+				fmt.Printf("Error verifying run: %v\n", err)
+				continue
+			}
+			fmt.Printf("%sRun verified.\n", icon("✅"))
+			return true
+		case "x", "reject":
+			reason := getUserInput("Rejection reason: ")
+			if reason == "" {
+				fmt.Println("A reason is required to reject a run.")
+				continue
+			}
+			if !confirm(fmt.Sprintf("Reject %s's run with reason %q? [y/N]: ", getPlayerDisplayName(run), reason)) {
+				fmt.Println("Cancelled.")
+				continue
+			}
+			if err := api.SetRunStatus(run.ID, "rejected", reason); err != nil {
+				fmt.Printf("Error rejecting run: %v\n", err)
+				continue
+			}
+			fmt.Printf("%sRun rejected.\n", icon("❌"))
+			return true
+		default:
+			fmt.Println("Invalid selection. Please try again.")
+		}
+	}
+}
+
+func handleLogin(api *SpeedrunAPI) {
+	fmt.Println("Your API key is shown at https://www.speedrun.com/settings/api")
+	key := getSecretInput("Enter your speedrun.com API key: ")
+	if key == "" {
+		fmt.Println("No key entered.")
+		os.Exit(1)
+	}
+	
+	api.apiKey = key
+	user, err := api.GetProfile()
+	if err != nil {
+		fmt.Printf("Error validating API key: %v\n", err)
+		os.Exit(1)
+	}
+	
+	if err := saveAPIKey(key); err != nil {
+		fmt.Printf("Error saving API key: %v\n", err)
+		os.Exit(1)
+	}
+	
+	fmt.Printf("%sLogged in as %s\n", icon("✅"), user.Names.International)
+}
+
+// loadRunNames maps category and level IDs to display names for runs.
+func loadRunNames(api *SpeedrunAPI, gameID string, runs []Run) map[string]string {
+	names := make(map[string]string)
+	
+	categories, err := api.GetGameCategories(gameID)
+	if err != nil {
+		logger.Warn("failed to load categories for queue", "error", err)
+	}
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	
+	for _, run := range runs {
+		if run.Level == "" {
+			continue
+		}
+		levels, err := api.GetGameLevels(gameID)
+		if err != nil {
+			logger.Warn("failed to load levels for queue", "error", err)
+		}
+		for _, level := range levels {
+			names[level.ID] = level.Name
+		}
+		break
+	}
+	
+	return names
+}
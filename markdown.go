@@ -0,0 +1,152 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBold   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalic = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	markdownCode   = regexp.MustCompile("`([^`]+)`")
+	ansiSequence   = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	orderedItem    = regexp.MustCompile(`^(\d+)[.)]\s+(.*)$`)
+)
+
+// renderMarkdown formats the Markdown subset used in speedrun.com rules
+// (headings, lists, block quotes, code blocks, bold, italics, links, and
+// inline code) as terminal lines wrapped to width.
+func renderMarkdown(text string, width int, colors Colors) []string {
+	var lines []string
+	inCodeBlock := false
+	
+	blankLine := func() {
+		if len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+	}
+	
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(raw, " \t")
+		trimmed := strings.TrimSpace(line)
+		
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		
+		if inCodeBlock {
+			lines = append(lines, "    "+line)
+			continue
+		}
+		
+		if trimmed == "" {
+			blankLine()
+			continue
+		}
+		
+		if trimmed == "---" || trimmed == "***" || trimmed == "___" {
+			lines = append(lines, horizontalRule(min(width, 40)))
+			continue
+		}
+		
+		if level := headingLevel(trimmed); level > 0 {
+			blankLine()
+			heading := renderInline(strings.TrimSpace(trimmed[level:]), colors)
+			style := colors.Bold
+			if level <= 2 {
+				style += colors.Underline
+			}
+			for _, wrapped := range wrapText(heading, width, "", "") {
+				lines = append(lines, style+wrapped+colors.Reset)
+			}
+			continue
+		}
+		
+		indent := strings.Repeat("  ", (len(line)-len(strings.TrimLeft(line, " \t")))/2)
+		
+		if marker, rest, ok := listItem(trimmed); ok {
+			prefix := indent + marker + " "
+			lines = append(lines, wrapText(renderInline(rest, colors), width, prefix, strings.Repeat(" ", displayWidth(prefix)))...)
+			continue
+		}
+		
+		if strings.HasPrefix(trimmed, ">") {
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			lines = append(lines, wrapText(renderInline(quote, colors), width, symbol("│", "|")+" ", symbol("│", "|")+" ")...)
+			continue
+		}
+		
+		lines = append(lines, wrapText(renderInline(trimmed, colors), width, indent, indent)...)
+	}
+	
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && level < 6 && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level >= len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+func listItem(line string) (string, string, bool) {
+	for _, bullet := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, bullet) {
+			return symbol("•", "*"), strings.TrimSpace(line[len(bullet):]), true
+		}
+	}
+	if match := orderedItem.FindStringSubmatch(line); match != nil {
+		return match[1] + ".", match[2], true
+	}
+	return "", "", false
+}
+
+func renderInline(text string, colors Colors) string {
+	text = markdownCode.ReplaceAllString(text, "$1")
+	text = markdownLink.ReplaceAllString(text, colors.Underline+"$1"+colors.Reset+" ("+colors.Blue+"$2"+colors.Reset+")")
+	text = markdownBold.ReplaceAllString(text, colors.Bold+"$1$2"+colors.Reset)
+	text = markdownItalic.ReplaceAllString(text, "$1")
+	return text
+}
+
+// wrapText word-wraps styled text so that no line's visible width exceeds
+// width. Words longer than a line are kept whole.
+func wrapText(text string, width int, firstPrefix, restPrefix string) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{strings.TrimRight(firstPrefix, " ")}
+	}
+	
+	var lines []string
+	current := firstPrefix
+	currentWidth := displayWidth(firstPrefix)
+	lineHasWords := false
+	
+	for _, word := range words {
+		wordWidth := displayWidth(word)
+		if lineHasWords && currentWidth+1+wordWidth > width {
+			lines = append(lines, current)
+			current = restPrefix
+			currentWidth = displayWidth(restPrefix)
+			lineHasWords = false
+		}
+		if lineHasWords {
+			current += " "
+			currentWidth++
+		}
+		current += word
+		currentWidth += wordWidth
+		lineHasWords = true
+	}
+	
+	return append(lines, current)
+}
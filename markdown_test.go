@@ -0,0 +1,157 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  []string
+	}{
+		{
+			name:  "heading and paragraph",
+			input: "# Title\nText",
+			width: 40,
+			want:  []string{"Title", "Text"},
+		},
+		{
+			name:  "blank lines collapse",
+			input: "one\n\n\n\ntwo",
+			width: 40,
+			want:  []string{"one", "", "two"},
+		},
+		{
+			name:  "lists and quotes",
+			input: "- one\n  - nested\n1) first\n> quoted text",
+			width: 40,
+			want:  []string{"• one", "  • nested", "1. first", "│ quoted text"},
+		},
+		{
+			name:  "code blocks keep spacing",
+			input: "```\ncode  here\n```",
+			width: 40,
+			want:  []string{"    code  here"},
+		},
+		{
+			name:  "rule",
+			input: "---",
+			width: 12,
+			want:  []string{"────────────"},
+		},
+		{
+			name:  "inline markup",
+			input: "**bold** _x_ *it* and [link](http://x) `code`",
+			width: 40,
+			want:  []string{"bold _x_ it and link (http://x) code"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderMarkdown(tt.input, tt.width, Colors{})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("renderMarkdown(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownWrapsToWidth(t *testing.T) {
+	rules := "## Timing and video requirements for every run\n" +
+		"Timing starts on the frame the file is selected and ends when the final **Bowser** hit lands.\n" +
+		"- Runs must include the [full game audio](https://www.speedrun.com/sm64/rules) and no cuts.\n" +
+		"> Emulator runs are only accepted on the approved versions."
+	
+	for _, width := range []int{24, 40, 80} {
+		for _, colors := range []Colors{{}, DefaultColors} {
+			lines := renderMarkdown(rules, width, colors)
+			
+			var words []string
+			for _, line := range lines {
+				if w := displayWidth(line); w > width && len(strings.Fields(ansiSequence.ReplaceAllString(line, ""))) > 2 {
+					t.Errorf("width %d: line %q is %d cells wide", width, line, w)
+				}
+				plain := strings.TrimLeft(ansiSequence.ReplaceAllString(line, ""), "•│ ")
+				words = append(words, strings.Fields(plain)...)
+			}
+			
+			// Wrapping only moves words between lines
+			want := "Timing and video requirements for every run Timing starts on the frame the file is selected and ends " +
+				"when the final Bowser hit lands. Runs must include the full game audio (https://www.speedrun.com/sm64/rules) " +
+				"and no cuts. Emulator runs are only accepted on the approved versions."
+			if got := strings.Join(words, " "); got != want {
+				t.Errorf("width %d: words changed:\n%s\nwant\n%s", width, got, want)
+			}
+		}
+	}
+	
+	// List continuations line up under the item text, quotes keep their bar
+	lines := renderMarkdown("- one two three four five six seven\n> eight nine ten eleven twelve", 16, Colors{})
+	want := []string{"• one two three", "  four five six", "  seven", "│ eight nine ten", "│ eleven twelve"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("renderMarkdown() = %q, want %q", lines, want)
+	}
+}
+
+// TestDisplayRules shows a category's rules from the fake server with its
+// subcategory rules, paging when they are taller than the terminal.
+func TestDisplayRules(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	categories, err := api.GetGameCategories("o1y9wo6q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var category *Category
+	for i := range categories {
+		if categories[i].Name == "120 Star" {
+			category = &categories[i]
+		}
+	}
+	if category == nil {
+		t.Fatal("120 Star not found")
+	}
+	subCategories, err := api.GetCategoryVariables(category.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections := categoryRulesSections(category, subCategories)
+	
+	t.Run("everything fits", func(t *testing.T) {
+		plainOutput(t, 60, 50)
+		out := captureStdout(t, func() { displayRules("120 Star Rules", sections) })
+		
+		for _, want := range []string{
+			"120 Star Rules",
+			"• Collect all 120 stars and defeat Bowser.",
+			"rules page (https://www.speedrun.com/sm64/rules)",
+			"120 Star - JP\nJapanese version (no BLJ fix).",
+			"120 Star - US\nUS version.",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("rules output is missing %q:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "-- More") {
+			t.Errorf("rules that fit were paged:\n%s", out)
+		}
+	})
+	
+	t.Run("paged and stopped", func(t *testing.T) {
+		plainOutput(t, 60, 10)
+		scriptInput(t, "q\n")
+		out := captureStdout(t, func() { displayRules("120 Star Rules", sections) })
+		
+		lines := strings.Split(out, "\n")
+		if len(lines) != 9 || !strings.HasPrefix(lines[8], "-- More (") {
+			t.Errorf("want one page of 8 lines and a More prompt, got:\n%s", out)
+		}
+		if strings.Contains(out, "US version.") {
+			t.Errorf("output continued after q:\n%s", out)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -11,23 +12,86 @@ type Game struct {
 		International string `json:"international"`
 		Japanese      string `json:"japanese"`
 	} `json:"names"`
+	Abbreviation string            `json:"abbreviation"`
+	Released     int               `json:"released"`
+	ReleaseDate  string            `json:"release-date"`
+	Weblink      string            `json:"weblink"`
+	Ruleset      Ruleset           `json:"ruleset"`
+	Platforms    []string          `json:"platforms"`
+	Regions      []string          `json:"regions"`
+	Genres       []string          `json:"genres"`
+	Engines      []string          `json:"engines"`
+	Developers   []string          `json:"developers"`
+	Publishers   []string          `json:"publishers"`
+	Moderators   map[string]string `json:"moderators"`
+	Categories   struct {
+		Data []Category `json:"data"`
+	} `json:"categories"`
+}
+
+type Ruleset struct {
+	ShowMilliseconds    bool     `json:"show-milliseconds"`
+	RequireVerification bool     `json:"require-verification"`
+	RequireVideo        bool     `json:"require-video"`
+	RunTimes            []string `json:"run-times"`
+	DefaultTime         string   `json:"default-time"`
+	EmulatorsAllowed    bool     `json:"emulators-allowed"`
+}
+
+// GameOverview is a game with its embedded resources resolved to names,
+// plus a summary of each category's leaderboard.
+type GameOverview struct {
+	Game       Game
+	Platforms  []string
+	Regions    []string
+	Genres     []string
+	Engines    []string
+	Developers []string
+	Publishers []string
+	Moderators []string
+	Categories []CategorySummary
+}
+
+type CategorySummary struct {
+	Category Category
+	Runners  int
+	Record   *Run
+}
+
+type Category struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Rules         string `json:"rules"`
+	Weblink       string `json:"weblink"`
+	Miscellaneous bool   `json:"miscellaneous"`
+}
+
+type Series struct {
+	ID    string `json:"id"`
+	Names struct {
+		International string `json:"international"`
+		Japanese      string `json:"japanese"`
+	} `json:"names"`
 	Abbreviation string `json:"abbreviation"`
-	Released     int    `json:"released"`
 	Weblink      string `json:"weblink"`
 }
 
-type Category struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Rules   string `json:"rules"`
-	Weblink string `json:"weblink"`
+// SeriesEntry is one row of the series overview: a game and the current
+// record in its most popular full-game category.
+type SeriesEntry struct {
+	Game     Game
+	Category Category
+	Runners  int
+	Record   *Run
+	Platform string
 }
 
 type Run struct {
 	ID       string `json:"id"`
 	Weblink  string `json:"weblink"`
 	Game     string `json:"game"`
+	Level    string `json:"level"`
 	Category string `json:"category"`
 	Date     string `json:"date"`
 	Submitted time.Time `json:"submitted"`
@@ -58,7 +122,8 @@ type Run struct {
 			URI string `json:"uri"`
 		} `json:"links"`
 	} `json:"videos"`
-	Comment string `json:"comment"`
+	Comment string            `json:"comment"`
+	Values  map[string]string `json:"values"`
 }
 
 type Leaderboard struct {
@@ -74,6 +139,12 @@ type Leaderboard struct {
 		Run   Run `json:"run"`
 	} `json:"runs"`
 	PlatformMap map[string]string `json:"-"`
+	PlayerMap   map[string]string `json:"-"`
+	Filter      string            `json:"-"`
+	TotalRuns   int               `json:"-"`
+	// AsOf is the date the board was requested at, empty for today
+	AsOf   string `json:"-"`
+	source string
 }
 
 type APIResponse struct {
@@ -95,6 +166,31 @@ type User struct {
 		International string `json:"international"`
 		Japanese      string `json:"japanese"`
 	} `json:"names"`
+	Weblink  string        `json:"weblink"`
+	Pronouns string        `json:"pronouns"`
+	Role     string        `json:"role"`
+	Signup   *time.Time    `json:"signup"`
+	Location *UserLocation `json:"location"`
+	Twitch   *UserLink     `json:"twitch"`
+	YouTube  *UserLink     `json:"youtube"`
+	Twitter  *UserLink     `json:"twitter"`
+}
+
+// UserLocation is where a user says they are from. The region is optional.
+type UserLocation struct {
+	Country *UserPlace `json:"country"`
+	Region  *UserPlace `json:"region"`
+}
+
+type UserPlace struct {
+	Code  string `json:"code"`
+	Names struct {
+		International string `json:"international"`
+	} `json:"names"`
+}
+
+type UserLink struct {
+	URI string `json:"uri"`
 }
 
 type UserRun struct {
@@ -131,6 +227,17 @@ type UserRun struct {
 	Place   int    `json:"place"`
 }
 
+// PersonalBest is a user's place on one leaderboard with the game,
+// category, and level it belongs to.
+type PersonalBest struct {
+	Place    int
+	Run      Run
+	Game     Game
+	Category Category
+	Level    *Level
+	Platform string
+}
+
 type Platform struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
@@ -153,6 +260,88 @@ type Variable struct {
 		} `json:"values"`
 	} `json:"values"`
 	IsSubcategory bool `json:"is-subcategory"`
+	Mandatory     bool `json:"mandatory"`
+	UserDefined   bool `json:"user-defined"`
+	Scope         struct {
+		Type  string `json:"type"`
+		Level string `json:"level"`
+	} `json:"scope"`
+}
+
+type Level struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Rules   string `json:"rules"`
+	Weblink string `json:"weblink"`
+}
+
+type RunSubmission struct {
+	Category  string                        `json:"category"`
+	Level     string                        `json:"level,omitempty"`
+	Date      string                        `json:"date"`
+	Region    string                        `json:"region,omitempty"`
+	Platform  string                        `json:"platform,omitempty"`
+	Verified  bool                          `json:"verified"`
+	Times     SubmissionTimes               `json:"times"`
+	Emulated  bool                          `json:"emulated"`
+	Video     string                        `json:"video,omitempty"`
+	Comment   string                        `json:"comment,omitempty"`
+	Variables map[string]SubmissionVariable `json:"variables,omitempty"`
+}
+
+type SubmissionTimes struct {
+	Realtime        float64 `json:"realtime,omitempty"`
+	RealtimeNoLoads float64 `json:"realtime_noloads,omitempty"`
+	Ingame          float64 `json:"ingame,omitempty"`
+}
+
+type SubmissionVariable struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type Notification struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+	Status  string    `json:"status"`
+	Text    string    `json:"text"`
+	Item    struct {
+		Rel string `json:"rel"`
+		URI string `json:"uri"`
+	} `json:"item"`
+	Links []struct {
+		Rel string `json:"rel"`
+		URI string `json:"uri"`
+	} `json:"links"`
+}
+
+func (n Notification) IsUnread() bool {
+	return n.Status == "unread"
+}
+
+// Kind classifies a notification for display from its item type and text.
+func (n Notification) Kind() string {
+	text := strings.ToLower(n.Text)
+	switch {
+	case n.Item.Rel == "run" && strings.Contains(text, "rejected"):
+		return "Rejected"
+	case n.Item.Rel == "run" && strings.Contains(text, "verified"):
+		return "Verified"
+	case n.Item.Rel == "run":
+		return "Run"
+	case n.Item.Rel == "post" || strings.Contains(text, "comment") || strings.Contains(text, "replied"):
+		return "Comment"
+	case n.Item.Rel == "game" || n.Item.Rel == "guide" || n.Item.Rel == "resource":
+		return "Moderation"
+	default:
+		return "Other"
+	}
+}
+
+type QueuePlacement struct {
+	Place   int
+	IsPB    bool
+	Checked bool
 }
 
 type SubCategory struct {
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strconv"
@@ -52,16 +51,52 @@ type UserChoice struct {
 	IsCategory bool
 	IsHelp   bool
 	IsUser   bool
+	IsSeries bool
+	IsRules  bool
 	IsNext   bool
 	IsPrev   bool
 	PageNum  int
 }
 
+// getUserInput reads one line through the line editor. Ctrl-C and the end
+// of input (Ctrl-D) leave the program.
 func getUserInput(prompt string) string {
-	fmt.Print(prompt)
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Scan()
-	return strings.TrimSpace(scanner.Text())
+	input, err := lineEditor.ReadLine(prompt)
+	return strings.TrimSpace(checkInputError(input, err))
+}
+
+// getCommandInput reads a line at the main prompt and records it in
+// history.
+func getCommandInput(prompt string) string {
+	input, err := lineEditor.ReadCommand(prompt)
+	return strings.TrimSpace(checkInputError(input, err))
+}
+
+// getSecretInput reads a line without echo or history, for API keys.
+func getSecretInput(prompt string) string {
+	input, err := lineEditor.ReadSecret(prompt)
+	if err == errEchoUnsupported {
+		fmt.Printf("Error: %v; set %s instead\n", err, APIKeyEnvVar)
+		os.Exit(1)
+	}
+	return strings.TrimSpace(checkInputError(input, err))
+}
+
+func checkInputError(input string, err error) string {
+	if err == errInterrupted {
+		os.Exit(130)
+	}
+	if err != nil {
+		fmt.Println()
+		fmt.Println(goodbye())
+		os.Exit(0)
+	}
+	return input
+}
+
+func confirm(prompt string) bool {
+	input := strings.ToLower(getUserInput(prompt))
+	return input == "y" || input == "yes"
 }
 
 func parseUserInput(input string) UserChoice {
@@ -85,6 +120,10 @@ func parseUserInput(input string) UserChoice {
 		choice.IsHelp = true
 	case "u", "user":
 		choice.IsUser = true
+	case "s", "series":
+		choice.IsSeries = true
+	case "i", "rules":
+		choice.IsRules = true
 	case "n", "next":
 		choice.IsNext = true
 	case "p", "prev":
@@ -109,6 +148,12 @@ func parseUserInput(input string) UserChoice {
 }
 
 func getUserChoice(prompt string, maxOptions int, allowBack bool) UserChoice {
+	return getMenuChoice(prompt, maxOptions, allowBack, false)
+}
+
+// getMenuChoice is getUserChoice for screens that also accept the 'i'
+// rules command.
+func getMenuChoice(prompt string, maxOptions int, allowBack, allowRules bool) UserChoice {
 	for {
 		input := getUserInput(prompt)
 		choice := parseUserInput(input)
@@ -117,6 +162,10 @@ func getUserChoice(prompt string, maxOptions int, allowBack bool) UserChoice {
 			return choice
 		}
 		
+		if choice.IsRules && allowRules {
+			return choice
+		}
+		
 		if choice.IsBack && allowBack {
 			return choice
 		}
@@ -212,54 +261,110 @@ func selectCategory(categories []Category) *Category {
 		return nil
 	}
 	
-	fmt.Printf("\nCategories:\n")
-	for i, cat := range categories {
-		fmt.Printf("%d. %s (%s)\n", i+1, cat.Name, cat.Type)
-	}
-	
-	choice := getUserChoice("\nEnter number to select, 'b' to go back, 'q' to quit: ", len(categories), true)
-	
-	if choice.IsQuit {
+	for {
+		fmt.Printf("\nCategories:\n")
+		for i, cat := range categories {
+			fmt.Printf("%d. %s (%s)\n", i+1, cat.Name, cat.Type)
+		}
+		
+		choice := getMenuChoice("\nEnter number to select, 'i' for rules, 'b' to go back, 'q' to quit: ", len(categories), true, true)
+		
+		if choice.IsQuit {
+			return nil
+		}
+		
+		if choice.IsBack {
+			return &Category{ID: "BACK"}
+		}
+		
+		if choice.IsRules {
+			rulesChoice := getUserChoice("Show rules for which category? ", len(categories), true)
+			if rulesChoice.Index >= 0 {
+				category := &categories[rulesChoice.Index]
+				displayRules(category.Name+" Rules", categoryRulesSections(category, nil))
+			}
+			continue
+		}
+		
+		if choice.Index >= 0 {
+			return &categories[choice.Index]
+		}
+		
 		return nil
 	}
-	
-	if choice.IsBack {
-		return &Category{ID: "BACK"}
-	}
-	
-	if choice.Index >= 0 {
-		return &categories[choice.Index]
-	}
-	
-	return nil
 }
 
-func selectSubCategory(subCategories []SubCategory) *SubCategory {
+func selectSubCategory(category *Category, subCategories []SubCategory) *SubCategory {
 	if len(subCategories) == 0 {
 		fmt.Println("No subcategories found.")
 		return nil
 	}
 	
-	fmt.Printf("\nSubcategories:\n")
-	for i, subCat := range subCategories {
-		fmt.Printf("%d. %s\n", i+1, subCat.Label)
-	}
-	
-	choice := getUserChoice("\nEnter number to select, 'b' to go back, 'q' to quit: ", len(subCategories), true)
-	
-	if choice.IsQuit {
+	for {
+		fmt.Printf("\nSubcategories:\n")
+		for i, subCat := range subCategories {
+			fmt.Printf("%d. %s\n", i+1, subCat.Label)
+		}
+		
+		choice := getMenuChoice("\nEnter number to select, 'i' for rules, 'b' to go back, 'q' to quit: ", len(subCategories), true, true)
+		
+		if choice.IsQuit {
+			return nil
+		}
+		
+		if choice.IsBack {
+			return &SubCategory{ID: "BACK"}
+		}
+		
+		if choice.IsRules {
+			displayRules(category.Name+" Rules", categoryRulesSections(category, subCategories))
+			continue
+		}
+		
+		if choice.Index >= 0 {
+			return &subCategories[choice.Index]
+		}
+		
 		return nil
 	}
-	
-	if choice.IsBack {
-		return &SubCategory{ID: "BACK"}
+}
+
+// selectOption lists labelled options and returns the chosen index, or -1
+// when the user skips an optional choice or goes back.
+func selectOption(title string, options []string, optional bool) int {
+	fmt.Printf("\n%s:\n", title)
+	for i, option := range options {
+		fmt.Printf("%d. %s\n", i+1, option)
 	}
 	
-	if choice.Index >= 0 {
-		return &subCategories[choice.Index]
+	prompt := "\nEnter number to select, 'b' to go back, 'q' to quit: "
+	if optional {
+		prompt = "\nEnter number to select, Enter to skip, 'q' to quit: "
 	}
 	
-	return nil
+	for {
+		input := getUserInput(prompt)
+		if input == "" && optional {
+			return -1
+		}
+		
+		choice := parseUserInput(input)
+		
+		if choice.IsQuit {
+			fmt.Println(goodbye())
+			os.Exit(0)
+		}
+		
+		if choice.IsBack {
+			return -1
+		}
+		
+		if choice.Index >= 0 && choice.Index < len(options) {
+			return choice.Index
+		}
+		
+		showInputError(len(options), true)
+	}
 }
 
 func handleLeaderboardNavigation(currentPage, totalPages int) UserChoice {
@@ -276,7 +381,9 @@ func handleLeaderboardNavigation(currentPage, totalPages int) UserChoice {
 		controls = append(controls, fmt.Sprintf("'p1-p%d' jump to page", totalPages))
 	}
 	
-	controls = append(controls, "'b' back", "'c' categories", "'q' quit", "'r' refresh")
+	controls = append(controls, "'/name' search", "'platform:X' / 'date>YYYY-MM-DD' / 'video' filter", "'clear' filters")
+	controls = append(controls, "'d' as of date", "'diff YYYY-MM-DD' changes since")
+	controls = append(controls, "'i' rules", "'b' back", "'c' categories", "'q' quit", "'r' refresh")
 	
 	fmt.Printf("%s%s\n", navigationText, strings.Join(controls, ", "))
 	input := getUserInput("Action: ")
@@ -312,33 +419,97 @@ func selectUser(users []User) *User {
 	return nil
 }
 
+func selectSeries(series []Series) *Series {
+	if len(series) == 0 {
+		fmt.Println("No series found.")
+		return nil
+	}
+	
+	if len(series) == 1 {
+		fmt.Printf("Found exact match: %s\n", series[0].Names.International)
+		return &series[0]
+	}
+	
+	fmt.Printf("\nFound %d series:\n", len(series))
+	for i, s := range series {
+		fmt.Printf("%d. %s (%s)\n", i+1, s.Names.International, s.Abbreviation)
+	}
+	
+	choice := getUserChoice("\nEnter number to select, 'q' to quit: ", len(series), false)
+	
+	if choice.IsQuit {
+		return nil
+	}
+	
+	if choice.Index >= 0 {
+		return &series[choice.Index]
+	}
+	
+	return nil
+}
+
 func showHelp() {
-	fmt.Println("\n📚 Help - Speedrun.com CLI")
-	fmt.Println("============================")
-	fmt.Println("Navigation Flow:")
-	fmt.Println("  1. Search for a game OR search for a user")
-	fmt.Println("     • Game: Search for a game → Select categories → View leaderboard")
-	fmt.Println("     • User: Search for a user → View their recent runs with placements")
-	fmt.Println("  2. For games: Select platform category and subcategory")
-	fmt.Println("  3. View leaderboard or user runs")
-	fmt.Println("\nControls:")
-	fmt.Println("  • Use numbers to select from lists")
-	fmt.Println("  • 'q' or ':q' - quit")
-	fmt.Println("  • 'b' or ':b' - go back")
-	fmt.Println("  • 'c' or ':c' - back to categories (from leaderboard)")
-	fmt.Println("  • 'r' - refresh current view")
-	fmt.Println("  • 'u' or 'user' - search for users instead of games")
-	fmt.Println("  • 'h' or 'help' - show this help")
-	fmt.Println("\nLeaderboard Navigation (for large leaderboards):")
-	fmt.Println("  • 'n' or 'next' - go to next page")
-	fmt.Println("  • 'p' or 'prev' - go to previous page")
-	fmt.Println("  • 'p1', 'p2', etc. - jump to specific page")
-	fmt.Println("\nFeatures:")
-	fmt.Println("  • Fuzzy game and user search")
-	fmt.Println("  • Platform categories with subcategories")
-	fmt.Println("  • Detailed leaderboards with filtering")
-	fmt.Println("  • User run history with placements and medals")
-	fmt.Println("  • Run times, players, platforms, videos")
-	fmt.Println("  • Pagination for large leaderboards (25 entries per page)")
+	say := func(line string) { fmt.Println(plainText(line)) }
+	
+	fmt.Printf("\n%sHelp - Speedrun.com CLI\n", icon("📚"))
+	say("============================")
+	say("Navigation Flow:")
+	say("  1. Search for a game, a series, OR a user")
+	say("     • Game: Search for a game → Game overview → Select categories → View leaderboard")
+	say("     • Series: Search for a series → View every game's WR → Select a game")
+	say("     • User: Search for a user → View their recent runs with placements")
+	say("  2. For games: Select platform category and subcategory")
+	say("  3. View leaderboard or user runs")
+	say("\nControls:")
+	say("  • Use numbers to select from lists")
+	say("  • 'q' or ':q' - quit")
+	say("  • 'b' or ':b' - go back")
+	say("  • 'c' or ':c' - back to categories (from leaderboard)")
+	say("  • 'r' - refresh current view")
+	say("  • 'i' or 'rules' - show category rules (categories, subcategories, leaderboard)")
+	say("  • 'u' or 'user' - search for users instead of games")
+	say("  • 's' or 'series' - search for a series (franchise) of games")
+	say("  • Paste a speedrun.com URL or type a game abbreviation (e.g. 'sm64') to open it directly")
+	say("  • 'queue <game>' - list unverified runs awaiting moderation")
+	say("  • 'v' / 'x' - verify or reject a queued run (requires an API key)")
+	say("  • 'inbox' - show your notifications (requires an API key)")
+	say("  • 'bookmarks' - open a bookmarked game ('*' on a game overview toggles the bookmark)")
+	say("  • 'h' or 'help' - show this help")
+	say("\nLeaderboard Navigation (for large leaderboards):")
+	say("  • 'n' or 'next' - go to next page")
+	say("  • 'p' or 'prev' - go to previous page")
+	say("  • 'p1', 'p2', etc. - jump to specific page")
+	say("  • '/name' - jump to the page of the next runner matching 'name' ('/' repeats)")
+	say("  • Filters narrow the board without reloading; combine terms with spaces:")
+	say("      platform:N64  runner:name  date>2023-01-01  date<=2020-12-31  video  !video  emu  !emu")
+	say("  • 'clear' - remove all filters")
+	say("  • 'd' or 'd YYYY-MM-DD' - show the leaderboard as it stood on a date ('d today' returns)")
+	say("  • 'diff YYYY-MM-DD' - new runners, rank changes, and drop-offs since a date")
+	say("\nEditing:")
+	say("  • Left/Right, Home/End, Ctrl-A/E/K/U/W - move and delete")
+	say("  • Up/Down - previous input (history is kept between sessions)")
+	say("  • Ctrl-R - search history, Tab - complete commands and game names")
+	say("\nFeatures:")
+	say("  • Fuzzy game and user search")
+	say("  • Platform categories with subcategories")
+	say("  • Detailed leaderboards with filtering")
+	say("  • User run history with placements and medals")
+	say("  • Runner profiles with medals, most-played games, and monthly activity ('speedrun-cli profile <user>')")
+	say("  • Run times, players, platforms, videos")
+	say("  • Pagination sized to the terminal (override with --page-size N or page_size in config.json)")
+	say("  • Tables fit the terminal width, hiding comment, emulator, and video columns when narrow")
+	say("  • Color themes (--theme NAME), --no-color or NO_COLOR, and --ascii for plain-text symbols")
+	say("  • Settings and profiles with 'speedrun-cli config list|get|set|unset|use'")
+	say("  • Verification queue with projected placements for moderators")
+	say("  • Run submission with 'speedrun-cli submit <game>'")
+	say("  • Shell completion with 'speedrun-cli completion bash|zsh|fish'")
+	say("  • Stream overlay files and browser source with 'speedrun-cli overlay'")
+	say("  • Atom and RSS feeds of new runs with 'speedrun-cli feed'")
+	say("  • JSON API for overlays and dashboards with 'speedrun-cli serve'")
+	say("  • Side-by-side board comparisons with 'speedrun-cli compare <game> <category> [<category>]'")
+	say("  • Game-wide stats: category popularity, top runners, platforms, and oldest records ('speedrun-cli game-stats <game>')")
+	say("  • Leaderboard changes between dates with 'speedrun-cli diff -from DATE <game> <category>'")
+	say("  • Offline snapshots with 'speedrun-cli sync <game>', browsed with --offline")
+	say("  • Offline development server with 'speedrun-cli fake-server'")
 	fmt.Println()
 }
\ No newline at end of file
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// GlobalOptions holds flags accepted before or after any subcommand that
+// are not settings themselves.
+type GlobalOptions struct {
+	Profile string
+	NoColor bool
+	// Record and Replay are fixture directories (see fixtures.go)
+	Record string
+	Replay string
+}
+
+var globalOptions GlobalOptions
+
+// parseGlobalFlags removes global flags from args and resolves settings
+// from them, the environment, and the config file. It returns the
+// remaining arguments. Setting flags are listed in settingDefinitions.
+func parseGlobalFlags(args []string) ([]string, error) {
+	remaining := args[:1:1]
+	flags := make(map[string]string)
+	
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		
+		if name == "--no-color" {
+			globalOptions.NoColor = true
+			continue
+		}
+		
+		def, isSetting := findSettingFlag(name)
+		if !isSetting && !isValueFlag(name) {
+			remaining = append(remaining, arg)
+			continue
+		}
+		
+		if !hasValue {
+			if isSetting && def.Kind == boolSetting {
+				value = "true"
+			} else if i+1 < len(args) {
+				i++
+				value = args[i]
+			} else {
+				return nil, fmt.Errorf("%s requires a value", name)
+			}
+		}
+		
+		switch {
+		case isSetting:
+			flags[def.Key] = value
+		case name == "--profile":
+			globalOptions.Profile = value
+		case name == "--record":
+			globalOptions.Record = value
+		case name == "--replay":
+			globalOptions.Replay = value
+		}
+	}
+	
+	if globalOptions.Record != "" && globalOptions.Replay != "" {
+		return remaining, fmt.Errorf("--record and --replay cannot be used together")
+	}
+	
+	cfg, err := loadConfig()
+	if err != nil {
+		return remaining, err
+	}
+	
+	profile := globalOptions.Profile
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+	if profile == "" {
+		profile = cfg.Profile
+	}
+	
+	resolved, err := resolveSettings(cfg, flags, profile)
+	settings = resolved
+	if err != nil {
+		return remaining, err
+	}
+	
+	if settings.Bool("offline") && (globalOptions.Record != "" || globalOptions.Replay != "") {
+		return remaining, fmt.Errorf("--offline cannot be used with --record or --replay")
+	}
+	
+	if err := configureLogging(); err != nil {
+		return remaining, err
+	}
+	
+	return remaining, configureTheme(cfg)
+}
+
+func findSettingFlag(flag string) (SettingDefinition, bool) {
+	for _, def := range settingDefinitions {
+		if def.Flag != "" && def.Flag == flag {
+			return def, true
+		}
+	}
+	return SettingDefinition{}, false
+}
+
+// isValueFlag reports whether flag is a global option that takes a value
+// but is not a setting.
+func isValueFlag(flag string) bool {
+	return flag == "--profile" || flag == "--record" || flag == "--replay"
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// The overlay keeps a runner's standing in one category up to date for a
+// stream: text files for OBS text sources, rendered from text/template,
+// and an auto-refreshing page for browser sources.
+
+const (
+	DefaultOverlayDir      = "overlay"
+	DefaultOverlayPort     = 8090
+	DefaultOverlayInterval = time.Minute
+	MinOverlayInterval     = 15 * time.Second
+)
+
+var defaultOverlayTemplates = map[string]string{
+	"wr.txt":   "{{if .WR.Time}}WR {{.WR.Time}} by {{.WR.Players}}{{else}}No WR yet{{end}}",
+	"pb.txt":   "PB {{if .PB}}{{.PB.Time}}{{else}}—{{end}}",
+	"rank.txt": "{{if .Rank}}{{ordinal .Rank}} of {{.Runners}}{{else}}Unranked{{end}}",
+	"gap.txt":  "{{if .Gap}}{{.Gap}} to WR{{end}}",
+}
+
+var overlayFuncs = template.FuncMap{
+	"ordinal": ordinal,
+	"upper":   strings.ToUpper,
+}
+
+// OverlayRun is a run as the templates see it.
+type OverlayRun struct {
+	Time     string  `json:"time"`
+	Seconds  float64 `json:"seconds"`
+	Players  string  `json:"players"`
+	Date     string  `json:"date"`
+	Platform string  `json:"platform"`
+	Video    string  `json:"video,omitempty"`
+}
+
+// OverlayData is what templates and the HTML page are rendered from.
+type OverlayData struct {
+	Game        string      `json:"game"`
+	Category    string      `json:"category"`
+	Subcategory string      `json:"subcategory,omitempty"`
+	Runner      string      `json:"runner"`
+	Runners     int         `json:"runners"`
+	WR          OverlayRun  `json:"wr"`
+	PB          *OverlayRun `json:"pb"`
+	Rank        int         `json:"rank"`
+	Gap         string      `json:"gap,omitempty"`
+	Updated     time.Time   `json:"updated"`
+}
+
+type overlay struct {
+	api         *SpeedrunAPI
+	game        *Game
+	category    *Category
+	subcategory *SubCategory
+	user        *User
+	outDir      string
+	templates   map[string]*template.Template
+	interval    time.Duration
+	
+	mu   sync.RWMutex
+	data *OverlayData
+}
+
+// templateFlag collects repeated -template flags.
+type templateFlag []string
+
+func (t *templateFlag) String() string {
+	return strings.Join(*t, ",")
+}
+
+func (t *templateFlag) Set(path string) error {
+	*t = append(*t, path)
+	return nil
+}
+
+func handleOverlay(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("overlay", flag.ExitOnError)
+	userName := fs.String("user", "", "runner to show the PB and rank of (default: the logged-in user)")
+	subcategoryName := fs.String("subcategory", "", "subcategory label, e.g. JP")
+	outDir := fs.String("out", DefaultOverlayDir, "directory to write text files to")
+	interval := fs.Duration("interval", DefaultOverlayInterval, "how often to refresh")
+	port := fs.Int("port", DefaultOverlayPort, "port for the HTML page (0 disables it)")
+	var templates templateFlag
+	fs.Var(&templates, "template", "template file to render into -out, named after the file without .tmpl (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli overlay [flags] <game> <category>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *interval < MinOverlayInterval {
+		fmt.Printf("Error: -interval must be at least %s\n", MinOverlayInterval)
+		os.Exit(1)
+	}
+	
+	// Progress messages would interleave with the refresh log
+	theme.Interactive = false
+	
+	o, err := newOverlay(api, fs.Arg(0), strings.Join(fs.Args()[1:], " "), *subcategoryName, *userName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	o.outDir = *outDir
+	o.interval = *interval
+	if o.templates, err = loadOverlayTemplates(templates); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(o.outDir, 0755); err != nil {
+		fmt.Printf("Error creating %s: %v\n", o.outDir, err)
+		os.Exit(1)
+	}
+	
+	title := o.game.Names.International + " - " + o.category.Name
+	if o.subcategory != nil {
+		title += " (" + o.subcategory.Label + ")"
+	}
+	fmt.Printf("%sOverlay for %s, runner %s\n", icon("🎥"), title, o.user.Names.International)
+	
+	names := make([]string, 0, len(o.templates))
+	for name := range o.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("Writing %s to %s every %s\n", strings.Join(names, ", "), o.outDir, o.interval)
+	
+	if *port != 0 {
+		addr := fmt.Sprintf("127.0.0.1:%d", *port)
+		fmt.Printf("Browser source: http://%s/\n", addr)
+		go func() {
+			if err := http.ListenAndServe(addr, o.handler()); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}()
+	}
+	
+	for {
+		o.refresh()
+		time.Sleep(o.interval)
+	}
+}
+
+// newOverlay resolves the game, category, subcategory, and runner once, so
+// refreshes only fetch the leaderboard and personal bests.
+func newOverlay(api *SpeedrunAPI, gameName, categoryName, subcategoryName, userName string) (*overlay, error) {
+	game, category, subcategory, err := resolveBoard(api, gameName, categoryName, subcategoryName)
+	if err != nil {
+		return nil, err
+	}
+	o := &overlay{api: api, game: game, category: category, subcategory: subcategory}
+	
+	if userName != "" {
+		o.user, err = api.GetUser(userName)
+	} else if api.HasAPIKey() {
+		o.user, err = api.GetProfile()
+	} else {
+		err = fmt.Errorf("pass -user NAME or log in with 'speedrun-cli login'")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// loadOverlayTemplates parses the built-in templates, or only the given
+// files when there are any.
+func loadOverlayTemplates(paths []string) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template)
+	if len(paths) == 0 {
+		for name, text := range defaultOverlayTemplates {
+			templates[name] = template.Must(template.New(name).Funcs(overlayFuncs).Parse(text))
+		}
+		return templates, nil
+	}
+	
+	for _, path := range paths {
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		
+		name := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+		if filepath.Ext(name) == "" {
+			name += ".txt"
+		}
+		tmpl, err := template.New(name).Funcs(overlayFuncs).Parse(string(text))
+		if err != nil {
+			return nil, fmt.Errorf("parsing template %s: %w", path, err)
+		}
+		templates[name] = tmpl
+	}
+	return templates, nil
+}
+
+// refresh fetches fresh data and rewrites the text files. On failure the
+// previous data stays up, so the stream keeps showing the last good values.
+func (o *overlay) refresh() {
+	data, err := o.fetch()
+	if err != nil {
+		logger.Warn("overlay refresh failed", "error", err)
+		fmt.Fprintf(os.Stderr, "%s refresh failed: %v\n", time.Now().Format("15:04:05"), err)
+		return
+	}
+	
+	o.mu.Lock()
+	o.data = data
+	o.mu.Unlock()
+	
+	for name, tmpl := range o.templates {
+		if err := writeOverlayFile(filepath.Join(o.outDir, name), tmpl, data); err != nil {
+			fmt.Fprintf(os.Stderr, "%s writing %s: %v\n", time.Now().Format("15:04:05"), name, err)
+		}
+	}
+	
+	rank := "unranked"
+	if data.Rank > 0 {
+		rank = ordinal(data.Rank)
+	}
+	wr := data.WR.Time
+	if wr == "" {
+		wr = "none yet"
+	}
+	fmt.Fprintf(os.Stderr, "%s updated: WR %s, rank %s\n", time.Now().Format("15:04:05"), wr, rank)
+}
+
+func (o *overlay) fetch() (*OverlayData, error) {
+	o.api.InvalidateCache(fmt.Sprintf("/leaderboards/%s/category/%s", o.game.ID, o.category.ID))
+	o.api.InvalidateCache(fmt.Sprintf("/users/%s/personal-bests", o.user.ID))
+	
+	subcategoryID := ""
+	varID := o.api.GetVariableIDForCategory(o.category.ID)
+	if o.subcategory != nil {
+		subcategoryID = o.subcategory.ID
+		// Without the variable the board would silently cover every
+		// subcategory, and so would the runner count
+		if varID == "" {
+			return nil, fmt.Errorf("could not find the subcategory variable of %s", o.category.Name)
+		}
+	}
+	lb, err := o.api.GetLeaderboard(o.game.ID, o.category.ID, "", subcategoryID, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := o.api.LoadPlayerNames(lb); err != nil {
+		return nil, err
+	}
+	
+	pbs, err := o.api.GetUserPersonalBests(o.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	data := &OverlayData{
+		Game:     o.game.Names.International,
+		Category: o.category.Name,
+		Runner:   o.user.Names.International,
+		Runners:  len(lb.Runs),
+		Updated:  time.Now(),
+	}
+	if o.subcategory != nil {
+		data.Subcategory = o.subcategory.Label
+	}
+	if len(lb.Runs) > 0 {
+		data.WR = o.newOverlayRun(lb.Runs[0].Run, lb.PlayerMap, lb.PlatformMap)
+	}
+	
+	for _, pb := range pbs {
+		if pb.Run.Category != o.category.ID || pb.Run.Level != "" {
+			continue
+		}
+		if subcategoryID != "" && pb.Run.Values[varID] != subcategoryID {
+			continue
+		}
+		
+		run := o.newOverlayRun(pb.Run, map[string]string{o.user.ID: o.user.Names.International}, lb.PlatformMap)
+		data.PB = &run
+		data.Rank = pb.Place
+		if len(lb.Runs) > 0 && run.Seconds > data.WR.Seconds {
+			data.Gap = "+" + formatGap(run.Seconds-data.WR.Seconds)
+		}
+		break
+	}
+	return data, nil
+}
+
+func (o *overlay) newOverlayRun(run Run, playerMap, platformMap map[string]string) OverlayRun {
+	result := OverlayRun{
+		Time:     formatTime(run.Times.Primary),
+		Seconds:  ptSeconds(run.Times.Primary),
+		Players:  strings.Join(runPlayerNames(o.api, run, playerMap), ", "),
+		Date:     run.Date,
+		Platform: getPlatformName(run, platformMap),
+	}
+	if len(run.Videos.Links) > 0 {
+		result.Video = run.Videos.Links[0].URI
+	}
+	return result
+}
+
+// writeOverlayFile replaces the file in one step, so a text source never
+// reads it half-written.
+func writeOverlayFile(path string, tmpl *template.Template, data *OverlayData) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+func (o *overlay) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		o.mu.RLock()
+		data := o.data
+		o.mu.RUnlock()
+		
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		overlayPage.Execute(w, struct {
+			Data    *OverlayData
+			Refresh int
+		}{data, int(o.interval.Seconds())})
+	})
+	mux.HandleFunc("GET /data.json", func(w http.ResponseWriter, r *http.Request) {
+		o.mu.RLock()
+		data := o.data
+		o.mu.RUnlock()
+		
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		json.NewEncoder(w).Encode(data)
+	})
+	return mux
+}
+
+// overlayPage has a transparent background for OBS browser sources and
+// reloads itself at the refresh interval.
+var overlayPage = htmltemplate.Must(htmltemplate.New("overlay").Funcs(htmltemplate.FuncMap{"ordinal": ordinal}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>speedrun-cli overlay</title>
+<style>
+  body { margin: 0; background: transparent; color: #fff; font: 600 28px/1.3 "Segoe UI", Helvetica, Arial, sans-serif; text-shadow: 0 0 4px #000, 0 2px 4px #000; }
+  .overlay { padding: 12px 16px; }
+  .title { font-size: 20px; opacity: 0.85; }
+  .label { display: inline-block; min-width: 3.5em; color: #ffd54f; }
+  .muted { opacity: 0.7; font-size: 20px; }
+</style>
+</head>
+<body>
+<div class="overlay">
+{{with .Data}}
+  <div class="title">{{.Game}} — {{.Category}}{{if .Subcategory}} ({{.Subcategory}}){{end}}</div>
+  {{if .WR.Time}}
+  <div><span class="label">WR</span> {{.WR.Time}} <span class="muted">by {{.WR.Players}}</span></div>
+  {{else}}
+  <div><span class="label">WR</span> <span class="muted">no WR yet</span></div>
+  {{end}}
+  {{if .PB}}
+  <div><span class="label">PB</span> {{.PB.Time}} <span class="muted">{{.Gap}}</span></div>
+  <div><span class="label">Rank</span> {{ordinal .Rank}} <span class="muted">of {{.Runners}}</span></div>
+  {{else}}
+  <div><span class="label">PB</span> <span class="muted">no run yet</span></div>
+  {{end}}
+{{else}}
+  <div class="muted">Loading…</div>
+{{end}}
+</div>
+</body>
+</html>
+`))
+
+// formatGap is formatSeconds with a minutes field always present, so a
+// 54 second gap reads "0:54" rather than "54".
+func formatGap(seconds float64) string {
+	gap := formatSeconds(seconds)
+	if strings.Contains(gap, ":") {
+		return gap
+	}
+	if seconds < 10 {
+		gap = "0" + gap
+	}
+	return "0:" + gap
+}
+
+// ordinal formats 1 as "1st", 2 as "2nd", 11 as "11th", and so on.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOverlayFetch builds overlays for sm64 120 Star against the fake
+// server. The board is split by the Version subcategory, Dwhatever has a PB
+// in both versions, and a level run is planted in the category to check it
+// never counts as a PB or reaches the board.
+func TestOverlayFetch(t *testing.T) {
+	api, server := startFakeAPI(t, fakeFaults{})
+	
+	var planted fakeObject
+	for _, run := range server.data.Runs {
+		if run["id"] == "r00047" {
+			data, _ := json.Marshal(run)
+			json.Unmarshal(data, &planted)
+		}
+	}
+	planted["id"] = "rlevel01"
+	planted["level"] = "5wkjv5d3"
+	planted["times"] = fakeObject{"primary": "PT1H", "primary_t": 3600, "realtime": "PT1H", "realtime_t": 3600}
+	server.data.Runs = append([]fakeObject{planted}, server.data.Runs...)
+	
+	cheeseUS := OverlayRun{Time: "1:44:10", Seconds: 6250, Players: "cheese", Date: "2023-05-14", Platform: "Wii U Virtual Console", Video: "https://www.youtube.com/watch?v=r00046"}
+	cheeseJP := OverlayRun{Time: "1:38:50", Seconds: 5930, Players: "cheese", Date: "2016-12-09", Platform: "Nintendo 64", Video: "https://www.youtube.com/watch?v=r00001"}
+	
+	tests := []struct {
+		name        string
+		subcategory string
+		runner      string
+		want        OverlayData
+	}{
+		{
+			name:        "US board skips the JP PB and the level run",
+			subcategory: "US",
+			runner:      "Dwhatever",
+			want: OverlayData{
+				Subcategory: "US", Runner: "Dwhatever", Runners: 12, WR: cheeseUS, Rank: 2, Gap: "+1:01",
+				PB: &OverlayRun{Time: "1:45:11", Seconds: 6311, Players: "Dwhatever", Date: "2026-10-03", Platform: "Wii U Virtual Console", Video: "https://www.youtube.com/watch?v=r00047"},
+			},
+		},
+		{
+			name:        "JP board",
+			subcategory: "jp",
+			runner:      "Dwhatever",
+			want: OverlayData{
+				Subcategory: "JP", Runner: "Dwhatever", Runners: 40, WR: cheeseJP, Rank: 4, Gap: "+1:37",
+				PB: &OverlayRun{Time: "1:40:27", Seconds: 6027, Players: "Dwhatever", Date: "2026-10-07", Platform: "Wii Virtual Console", Video: "https://www.youtube.com/watch?v=r00004"},
+			},
+		},
+		{
+			name:        "world record holder has no gap",
+			subcategory: "US",
+			runner:      "cheese",
+			want:        OverlayData{Subcategory: "US", Runner: "cheese", Runners: 12, WR: cheeseUS, Rank: 1, PB: &cheeseUS},
+		},
+		{
+			name:        "no run in the subcategory",
+			subcategory: "US",
+			runner:      "Suigi",
+			want:        OverlayData{Subcategory: "US", Runner: "Suigi", Runners: 12, WR: cheeseUS},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := newOverlay(api, "sm64", "120 Star", tt.subcategory, tt.runner)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := o.fetch()
+			if err != nil {
+				t.Fatal(err)
+			}
+			
+			want := tt.want
+			want.Game, want.Category, want.Updated = "Super Mario 64", "120 Star", got.Updated
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("fetch() = %s\nwant       %s", overlayJSON(t, got), overlayJSON(t, &want))
+			}
+		})
+	}
+}
+
+// TestOverlayServesRefreshedData refreshes an overlay, then reads the text
+// files and the browser source endpoints.
+func TestOverlayServesRefreshedData(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	o, err := newOverlay(api, "sm64", "120 Star", "US", "Dwhatever")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.outDir = t.TempDir()
+	o.interval = time.Minute
+	o.templates, _ = loadOverlayTemplates(nil)
+	
+	// refresh reports progress on stderr
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	savedStderr := os.Stderr
+	os.Stderr = devNull
+	defer func() { os.Stderr = savedStderr; devNull.Close() }()
+	o.refresh()
+	
+	for name, want := range map[string]string{
+		"wr.txt":   "WR 1:44:10 by cheese",
+		"pb.txt":   "PB 1:45:11",
+		"rank.txt": "2nd of 12",
+		"gap.txt":  "+1:01 to WR",
+	} {
+		data, err := os.ReadFile(filepath.Join(o.outDir, name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q (%v), want %q", name, data, err, want)
+		}
+	}
+	
+	overlayServer := httptest.NewServer(o.handler())
+	defer overlayServer.Close()
+	
+	resp, err := overlayServer.Client().Get(overlayServer.URL + "/data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	var served OverlayData
+	if err := json.NewDecoder(resp.Body).Decode(&served); err != nil {
+		t.Fatal(err)
+	}
+	served.Updated = o.data.Updated
+	if !reflect.DeepEqual(served, *o.data) {
+		t.Errorf("/data.json = %s\nwant         %s", overlayJSON(t, &served), overlayJSON(t, o.data))
+	}
+	
+	page, err := overlayServer.Client().Get(overlayServer.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer page.Body.Close()
+	html, _ := io.ReadAll(page.Body)
+	for _, want := range []string{"Super Mario 64 — 120 Star (US)", "1:44:10", "by cheese", "1:45:11", "2nd", "of 12", `content="60"`} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("overlay page is missing %q", want)
+		}
+	}
+}
+
+func overlayJSON(t *testing.T, data *OverlayData) string {
+	t.Helper()
+	out, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "1st"}, {2, "2nd"}, {3, "3rd"}, {4, "4th"}, {10, "10th"},
+		{11, "11th"}, {12, "12th"}, {13, "13th"},
+		{21, "21st"}, {22, "22nd"}, {23, "23rd"},
+		{101, "101st"}, {111, "111th"}, {112, "112th"}, {113, "113th"},
+	}
+	
+	for _, tt := range tests {
+		if got := ordinal(tt.n); got != tt.want {
+			t.Errorf("ordinal(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatGap(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0.5, "0:00.500"},
+		{1.5, "0:01.500"},
+		{5, "0:05"},
+		{54, "0:54"},
+		{65, "1:05"},
+		{3600, "1:00:00"},
+		{3725.25, "1:02:05.250"},
+	}
+	
+	for _, tt := range tests {
+		if got := formatGap(tt.seconds); got != tt.want {
+			t.Errorf("formatGap(%v) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
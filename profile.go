@@ -0,0 +1,301 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A runner profile sums up a user: who they are, how many runs they have
+// verified and in how many games, their medals, the games they run most,
+// and how active they have been over the last year.
+
+const (
+	ProfileTopGames       = 5
+	ProfileActivityMonths = 12
+	ActivityBarWidth      = 30
+)
+
+// UserProfile is a user with the aggregates shown on the profile page.
+type UserProfile struct {
+	User     *User
+	Runs     int
+	Capped   bool
+	Games    int
+	Medals   [MaxRankWithMedal]int
+	LevelWRs int
+	TopGames []ProfileGame
+	Activity []ProfileMonth
+}
+
+// ProfileGame is one of a runner's most-played games.
+type ProfileGame struct {
+	Name string
+	Runs int
+	PBs  int
+	Best int
+}
+
+// ProfileMonth counts the runs a user did in one month.
+type ProfileMonth struct {
+	Month time.Time
+	Runs  int
+}
+
+func handleProfile(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("profile", flag.ExitOnError)
+	showRuns := fs.Bool("runs", false, "also list the runner's recent runs")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli profile [flags] <user>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	
+	user, err := api.GetUser(fs.Arg(0))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	profile, err := loadUserProfile(api, user)
+	if err != nil {
+		fmt.Printf("Error loading profile: %v\n", err)
+		os.Exit(1)
+	}
+	displayUserProfile(profile)
+	
+	if *showRuns {
+		runs, err := api.GetUserRuns(user.ID)
+		if err != nil {
+			fmt.Printf("Error loading user runs: %v\n", err)
+			os.Exit(1)
+		}
+		displayUserRuns(user, runs)
+	}
+}
+
+// loadUserProfile fetches a user's run history and personal bests and
+// aggregates them.
+func loadUserProfile(api *SpeedrunAPI, user *User) (*UserProfile, error) {
+	runs, capped, err := api.GetUserRunHistory(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	pbs, err := api.GetUserPersonalBests(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	profile := &UserProfile{User: user, Runs: len(runs), Capped: capped}
+	
+	gameNames := make(map[string]string)
+	games := make(map[string]*ProfileGame)
+	gameFor := func(gameID string) *ProfileGame {
+		if games[gameID] == nil {
+			games[gameID] = &ProfileGame{}
+		}
+		return games[gameID]
+	}
+	
+	for _, pb := range pbs {
+		if pb.Game.ID != "" {
+			gameNames[pb.Game.ID] = pb.Game.Names.International
+		}
+		game := gameFor(pb.Run.Game)
+		game.PBs++
+		if pb.Place > 0 && (game.Best == 0 || pb.Place < game.Best) {
+			game.Best = pb.Place
+		}
+		if pb.Place >= 1 && pb.Place <= MaxRankWithMedal {
+			profile.Medals[pb.Place-1]++
+			if pb.Place == 1 && pb.Level != nil {
+				profile.LevelWRs++
+			}
+		}
+	}
+	
+	now := time.Now()
+	firstMonth := time.Date(now.Year(), now.Month()-ProfileActivityMonths+1, 1, 0, 0, 0, 0, time.UTC)
+	profile.Activity = make([]ProfileMonth, ProfileActivityMonths)
+	for i := range profile.Activity {
+		profile.Activity[i].Month = firstMonth.AddDate(0, i, 0)
+	}
+	
+	for _, run := range runs {
+		gameFor(run.Game).Runs++
+		
+		date, err := time.Parse("2006-01-02", run.Date)
+		if err != nil || date.Before(firstMonth) {
+			continue
+		}
+		month := (date.Year()-firstMonth.Year())*12 + int(date.Month()-firstMonth.Month())
+		if month < len(profile.Activity) {
+			profile.Activity[month].Runs++
+		}
+	}
+	profile.Games = len(games)
+	
+	ranked := make([]string, 0, len(games))
+	for gameID := range games {
+		ranked = append(ranked, gameID)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := games[ranked[i]], games[ranked[j]]
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return ranked[i] < ranked[j]
+	})
+	if len(ranked) > ProfileTopGames {
+		ranked = ranked[:ProfileTopGames]
+	}
+	
+	for _, gameID := range ranked {
+		game := games[gameID]
+		game.Name = gameNames[gameID]
+		if game.Name == "" {
+			if found, err := api.GetGame(gameID); err == nil {
+				game.Name = found.Names.International
+			} else {
+				game.Name = gameID
+			}
+		}
+		profile.TopGames = append(profile.TopGames, *game)
+	}
+	
+	return profile, nil
+}
+
+func displayUserProfile(profile *UserProfile) {
+	colors := theme.Colors
+	user := profile.User
+	
+	fmt.Printf("\n%s%s\n", icon("👤"), user.Names.International)
+	if user.Names.Japanese != "" {
+		fmt.Printf("   %s\n", user.Names.Japanese)
+	}
+	if user.Weblink != "" {
+		fmt.Printf("%s%s\n", icon("📊"), user.Weblink)
+	}
+	fmt.Println()
+	
+	joined := EmptyValuePlaceholder
+	if user.Signup != nil {
+		joined = user.Signup.Format("2006-01-02")
+	}
+	pronouns := user.Pronouns
+	if pronouns == "" {
+		pronouns = EmptyValuePlaceholder
+	}
+	
+	fmt.Printf("Location:    %s\n", userLocation(user.Location))
+	fmt.Printf("Pronouns:    %s\n", pronouns)
+	fmt.Printf("Joined:      %s\n", joined)
+	fmt.Printf("Links:       %s\n", joinOrPlaceholder(userLinks(user)))
+	
+	runs := fmt.Sprintf("%d", profile.Runs)
+	if profile.Capped {
+		runs += "+"
+	}
+	worldRecords := fmt.Sprintf("%d", profile.Medals[0])
+	if profile.LevelWRs > 0 {
+		worldRecords += fmt.Sprintf(" (%d individual level)", profile.LevelWRs)
+	}
+	
+	fmt.Printf("\n%sStats\n", icon("📈"))
+	fmt.Printf("Runs:        %s verified\n", runs)
+	fmt.Printf("Games:       %d\n", profile.Games)
+	fmt.Printf("WRs:         %s\n", worldRecords)
+	fmt.Printf("Medals:      %s%s %d%s  %s%s %d%s  %s%s %d%s\n",
+		colors.Gold, symbol("🥇", "1st"), profile.Medals[0], colors.Reset,
+		colors.Silver, symbol("🥈", "2nd"), profile.Medals[1], colors.Reset,
+		colors.Bronze, symbol("🥉", "3rd"), profile.Medals[2], colors.Reset)
+	
+	fmt.Printf("\n%sMost-Played Games\n", icon("🎮"))
+	if len(profile.TopGames) == 0 {
+		fmt.Println("No verified runs found for this user.")
+	} else {
+		displayProfileGames(profile.TopGames, colors)
+	}
+	
+	fmt.Printf("\n%sActivity (last %d months)\n", icon("📅"), ProfileActivityMonths)
+	displayActivity(profile.Activity)
+}
+
+func displayProfileGames(games []ProfileGame, colors Colors) {
+	names := make([]string, len(games))
+	for i, game := range games {
+		names[i] = game.Name
+	}
+	columns := []TableColumn{
+		textColumn("Game", names, GameColumnMaxWidth, MinTextColumnWidth, 0),
+		{Title: "Runs", Width: 6},
+		{Title: "PBs", Width: 5},
+		{Title: "Best", Width: RankColumnWidth},
+	}
+	termWidth, _, _ := detectedTerminalSize()
+	fitColumns(columns, termWidth)
+	
+	fmt.Println(tableHeader(columns))
+	fmt.Println(horizontalRule(tableWidth(columns)))
+	
+	for i, game := range games {
+		best := EmptyValuePlaceholder
+		if game.Best > 0 {
+			best = strings.TrimRight(formatRank(game.Best, colors), " ")
+		}
+		fmt.Println(formatTableRow(columns, []string{names[i], fmt.Sprintf("%d", game.Runs), fmt.Sprintf("%d", game.PBs), best}))
+	}
+}
+
+// displayActivity draws one bar per month, scaled to the busiest month.
+func displayActivity(months []ProfileMonth) {
+	busiest := 0
+	for _, month := range months {
+		if month.Runs > busiest {
+			busiest = month.Runs
+		}
+	}
+	if busiest == 0 {
+		fmt.Println("No runs in this period.")
+		return
+	}
+	
+	bar := symbol("█", "#")
+	for _, month := range months {
+		width := month.Runs * ActivityBarWidth / busiest
+		if width == 0 && month.Runs > 0 {
+			width = 1
+		}
+		fmt.Printf("%s  %-*s %d\n", month.Month.Format("Jan 2006"), ActivityBarWidth, strings.Repeat(bar, width), month.Runs)
+	}
+}
+
+func userLocation(location *UserLocation) string {
+	if location == nil || location.Country == nil {
+		return EmptyValuePlaceholder
+	}
+	name := location.Country.Names.International
+	if location.Region != nil && location.Region.Names.International != "" {
+		name = location.Region.Names.International + ", " + name
+	}
+	return name
+}
+
+func userLinks(user *User) []string {
+	var links []string
+	for _, link := range []*UserLink{user.Twitch, user.YouTube, user.Twitter} {
+		if link != nil && link.URI != "" {
+			links = append(links, link.URI)
+		}
+	}
+	return links
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// speedrun.com allows about 100 requests a minute per client. Concurrent
+// board fetches (game stats, series, overviews) would burn through that in
+// seconds, so every request that goes to the network first takes a token
+// from a bucket shared by the API client.
+
+const RequestsPerMinute = 100
+
+// rateLimiter is a token bucket holding a minute's worth of requests,
+// refilled at the configured rate, so short bursts never wait. A nil
+// limiter never waits.
+type rateLimiter struct {
+	mu     sync.Mutex
+	tokens float64
+	burst  float64
+	rate   float64 // tokens per second
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter for perMinute requests, or nil when
+// perMinute is 0.
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{tokens: float64(perMinute), burst: float64(perMinute), rate: float64(perMinute) / 60, last: time.Now()}
+}
+
+// wait blocks until a token is available and takes it.
+func (l *rateLimiter) wait() {
+	if l == nil {
+		return
+	}
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+		
+		logger.Debug("rate limited", "wait", delay)
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// serve exposes a small HTTP API over SpeedrunAPI for overlays and
+// dashboards. Responses are flattened: names instead of IDs, times in
+// seconds, and no embeds or pagination to unwrap. Requests go through the
+// client's response cache, so polling the same board is cheap.
+
+const DefaultServePort = 8080
+
+type ServeCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type ServeGame struct {
+	ID           string          `json:"id"`
+	Abbreviation string          `json:"abbreviation"`
+	Name         string          `json:"name"`
+	Released     int             `json:"released,omitempty"`
+	Weblink      string          `json:"weblink"`
+	Categories   []ServeCategory `json:"categories,omitempty"`
+}
+
+type ServeTimes struct {
+	Primary         float64 `json:"primary"`
+	Realtime        float64 `json:"realtime,omitempty"`
+	RealtimeNoLoads float64 `json:"realtime_noloads,omitempty"`
+	Ingame          float64 `json:"ingame,omitempty"`
+}
+
+type ServeRun struct {
+	Place    int        `json:"place"`
+	ID       string     `json:"id"`
+	Players  []string   `json:"players"`
+	Time     float64    `json:"time"`
+	TimeText string     `json:"time_text"`
+	Times    ServeTimes `json:"times"`
+	Platform string     `json:"platform"`
+	Emulated bool       `json:"emulated"`
+	Date     string     `json:"date"`
+	Video    string     `json:"video,omitempty"`
+	Comment  string     `json:"comment,omitempty"`
+	Weblink  string     `json:"weblink"`
+}
+
+type ServeLeaderboard struct {
+	Game      ServeGame         `json:"game"`
+	Category  ServeCategory     `json:"category"`
+	Variables map[string]string `json:"variables"`
+	Weblink   string            `json:"weblink"`
+	Runs      []ServeRun        `json:"runs"`
+}
+
+type ServePersonalBest struct {
+	Game      ServeGame         `json:"game"`
+	Category  ServeCategory     `json:"category"`
+	Level     string            `json:"level,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+	Run       ServeRun          `json:"run"`
+}
+
+type ServeUserBests struct {
+	ID            string              `json:"id"`
+	Name          string              `json:"name"`
+	PersonalBests []ServePersonalBest `json:"personal_bests"`
+}
+
+func handleServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	port := fs.Int("port", DefaultServePort, "port to listen on")
+	host := fs.String("host", "127.0.0.1", "address to bind (use 0.0.0.0 to accept remote connections)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli serve [flags]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	// Progress messages would interleave with the request log
+	theme.Interactive = false
+	if err := enableServerLogging(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	server := &proxyServer{api: NewSpeedrunAPI()}
+	addr := fmt.Sprintf("%s:%d", *host, *port)
+	fmt.Printf("Serving on http://%s\n", addr)
+	fmt.Println("  GET /games?q=NAME")
+	fmt.Println("  GET /leaderboard/{game}/{category}?vars=NAME=VALUE,...&top=N")
+	fmt.Println("  GET /users/{name}/pbs")
+	fmt.Println("  GET /feed/game/{game}[/{category}], /feed/user/{name}?format=atom|rss")
+	if err := http.ListenAndServe(addr, server.handler()); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type proxyServer struct {
+	api *SpeedrunAPI
+}
+
+// rawResponse is a handler result sent as-is rather than encoded as JSON.
+type rawResponse struct {
+	ContentType string
+	Body        []byte
+}
+
+// proxyError is an error with the HTTP status to answer it with.
+type proxyError struct {
+	Status  int
+	Message string
+}
+
+func (e *proxyError) Error() string {
+	return e.Message
+}
+
+func (p *proxyServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /games", p.serve(p.games))
+	mux.HandleFunc("GET /leaderboard/{game}/{category}", p.serve(p.leaderboard))
+	mux.HandleFunc("GET /users/{name}/pbs", p.serve(p.personalBests))
+	mux.HandleFunc("GET /feed/game/{game}", p.serve(p.feed))
+	mux.HandleFunc("GET /feed/game/{game}/{category}", p.serve(p.feed))
+	mux.HandleFunc("GET /feed/user/{name}", p.serve(p.feed))
+	mux.HandleFunc("/", p.serve(func(r *http.Request) (interface{}, error) {
+		return nil, &proxyError{http.StatusNotFound, "not found"}
+	}))
+	return mux
+}
+
+// serve turns a handler's result into a JSON response, mapping upstream
+// 404s to 404 and other upstream failures to 502.
+func (p *proxyServer) serve(handle func(*http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		result, err := handle(r)
+		
+		status := http.StatusOK
+		if err != nil {
+			var proxyErr *proxyError
+			var apiErr *APIError
+			switch {
+			case errors.As(err, &proxyErr):
+				status = proxyErr.Status
+			case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+				status = http.StatusNotFound
+			default:
+				status = http.StatusBadGateway
+			}
+			result = map[string]string{"error": err.Error()}
+		}
+		
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if raw, ok := result.(*rawResponse); ok {
+			w.Header().Set("Content-Type", raw.ContentType)
+			w.WriteHeader(status)
+			w.Write(raw.Body)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(result)
+		}
+		
+		logAccess(r, status, time.Since(start))
+	}
+}
+
+func (p *proxyServer) games(r *http.Request) (interface{}, error) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		return nil, &proxyError{http.StatusBadRequest, "missing q parameter"}
+	}
+	
+	games, err := p.api.SearchGames(query)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := make([]ServeGame, 0, len(games))
+	for _, game := range games {
+		result = append(result, newServeGame(game, game.Categories.Data))
+	}
+	return result, nil
+}
+
+func (p *proxyServer) leaderboard(r *http.Request) (interface{}, error) {
+	game, err := p.api.GetGame(r.PathValue("game"))
+	if err != nil {
+		return nil, err
+	}
+	
+	categories, err := p.api.GetGameCategories(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	category := matchCategoryFragment(categories, r.PathValue("category"))
+	if category == nil {
+		return nil, &proxyError{http.StatusNotFound, fmt.Sprintf("no category %q in %s (available: %s)", r.PathValue("category"), game.Names.International, strings.Join(categoryNames(categories), ", "))}
+	}
+	if category.Type != "per-game" {
+		return nil, &proxyError{http.StatusBadRequest, fmt.Sprintf("%s is a per-level category; only full-game leaderboards are served", category.Name)}
+	}
+	
+	values, labels, err := p.resolveVariables(category.ID, r.URL.Query().Get("vars"))
+	if err != nil {
+		return nil, err
+	}
+	
+	top := 0
+	if raw := r.URL.Query().Get("top"); raw != "" {
+		if top, err = strconv.Atoi(raw); err != nil || top < 0 {
+			return nil, &proxyError{http.StatusBadRequest, fmt.Sprintf("invalid top %q", raw)}
+		}
+	}
+	
+	lb, err := p.api.GetBoard(game.ID, category.ID, values)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := ServeLeaderboard{
+		Game:      newServeGame(*game, nil),
+		Category:  newServeCategory(*category),
+		Variables: labels,
+		Weblink:   lb.Weblink,
+		Runs:      []ServeRun{},
+	}
+	for _, entry := range lb.Runs {
+		if top > 0 && entry.Place > top {
+			break
+		}
+		result.Runs = append(result.Runs, p.newServeRun(entry.Place, entry.Run, lb.PlayerMap, lb.PlatformMap))
+	}
+	return result, nil
+}
+
+// resolveVariables turns "Name=Value,..." into variable and value IDs.
+// Names and values may be given by ID or, case-insensitively, by label.
+// The labels map is keyed by variable name for the response.
+func (p *proxyServer) resolveVariables(categoryID, spec string) (map[string]string, map[string]string, error) {
+	values := make(map[string]string)
+	labels := make(map[string]string)
+	if strings.TrimSpace(spec) == "" {
+		return values, labels, nil
+	}
+	
+	inputs := variableFlag{}
+	for _, pair := range strings.Split(spec, ",") {
+		if err := inputs.Set(pair); err != nil {
+			return nil, nil, &proxyError{http.StatusBadRequest, err.Error()}
+		}
+	}
+	
+	variables, err := p.api.GetVariables(categoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	used := make(map[string]bool)
+	for _, variable := range variables {
+		input, key := lookupVariableInput(inputs, variable)
+		if key == "" {
+			continue
+		}
+		used[key] = true
+		
+		for _, valueID := range sortedValueIDs(variable) {
+			label := variable.Values.Values[valueID].Label
+			if valueID == input || strings.EqualFold(label, input) {
+				values[variable.ID] = valueID
+				labels[variable.Name] = label
+				break
+			}
+		}
+		if values[variable.ID] == "" {
+			var options []string
+			for _, valueID := range sortedValueIDs(variable) {
+				options = append(options, variable.Values.Values[valueID].Label)
+			}
+			return nil, nil, &proxyError{http.StatusBadRequest, fmt.Sprintf("unknown value %q for %s (available: %s)", input, variable.Name, strings.Join(options, ", "))}
+		}
+	}
+	
+	for name := range inputs {
+		if !used[name] {
+			return nil, nil, &proxyError{http.StatusBadRequest, fmt.Sprintf("unknown variable %q", name)}
+		}
+	}
+	return values, labels, nil
+}
+
+func (p *proxyServer) personalBests(r *http.Request) (interface{}, error) {
+	user, err := p.api.GetUser(r.PathValue("name"))
+	if err != nil {
+		return nil, err
+	}
+	
+	pbs, err := p.api.GetUserPersonalBests(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := ServeUserBests{
+		ID:            user.ID,
+		Name:          user.Names.International,
+		PersonalBests: make([]ServePersonalBest, 0, len(pbs)),
+	}
+	for _, pb := range pbs {
+		platforms := map[string]string{pb.Run.System.Platform: pb.Platform}
+		entry := ServePersonalBest{
+			Game:     newServeGame(pb.Game, nil),
+			Category: newServeCategory(pb.Category),
+			Run:      p.newServeRun(pb.Place, pb.Run, map[string]string{user.ID: user.Names.International}, platforms),
+		}
+		if pb.Level != nil {
+			entry.Level = pb.Level.Name
+		}
+		entry.Variables = subcategoryLabels(p.api, pb.Category.ID, pb.Run.Values)
+		result.PersonalBests = append(result.PersonalBests, entry)
+	}
+	sort.SliceStable(result.PersonalBests, func(i, j int) bool {
+		a, b := result.PersonalBests[i], result.PersonalBests[j]
+		if a.Game.Name != b.Game.Name {
+			return a.Game.Name < b.Game.Name
+		}
+		return a.Run.Place < b.Run.Place
+	})
+	return result, nil
+}
+
+// feed serves a game, category, or user feed. format=rss switches from
+// Atom, and max sets the number of runs.
+func (p *proxyServer) feed(r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = "atom"
+	}
+	if format != "atom" && format != "rss" {
+		return nil, &proxyError{http.StatusBadRequest, fmt.Sprintf("unknown format %q (use atom or rss)", format)}
+	}
+	
+	max := DefaultFeedEntries
+	if raw := query.Get("max"); raw != "" {
+		var err error
+		if max, err = strconv.Atoi(raw); err != nil || max < 1 || max > MaxFeedEntries {
+			return nil, &proxyError{http.StatusBadRequest, fmt.Sprintf("max must be between 1 and %d", MaxFeedEntries)}
+		}
+	}
+	
+	var feed *Feed
+	var err error
+	if name := r.PathValue("name"); name != "" {
+		feed, err = buildUserFeed(p.api, name, max)
+	} else {
+		feed, err = buildGameFeed(p.api, r.PathValue("game"), r.PathValue("category"), max)
+	}
+	if err != nil {
+		if !errors.As(err, new(*APIError)) {
+			err = &proxyError{http.StatusNotFound, err.Error()}
+		}
+		return nil, err
+	}
+	
+	document, contentType, err := renderFeed(feed, format)
+	if err != nil {
+		return nil, err
+	}
+	return &rawResponse{ContentType: contentType, Body: document}, nil
+}
+
+// subcategoryLabels names the subcategory a run is on, e.g.
+// {"Version": "JP"}, so PBs in the same category can be told apart.
+func subcategoryLabels(api *SpeedrunAPI, categoryID string, values map[string]string) map[string]string {
+	variables, err := api.GetVariables(categoryID)
+	if err != nil {
+		return nil
+	}
+	
+	labels := make(map[string]string)
+	for _, variable := range variables {
+		if value, ok := variable.Values.Values[values[variable.ID]]; ok && variable.IsSubcategory {
+			labels[variable.Name] = value.Label
+		}
+	}
+	return labels
+}
+
+func newServeGame(game Game, categories []Category) ServeGame {
+	result := ServeGame{
+		ID:           game.ID,
+		Abbreviation: game.Abbreviation,
+		Name:         game.Names.International,
+		Released:     game.Released,
+		Weblink:      game.Weblink,
+	}
+	for _, category := range categories {
+		result.Categories = append(result.Categories, newServeCategory(category))
+	}
+	return result
+}
+
+func newServeCategory(category Category) ServeCategory {
+	return ServeCategory{ID: category.ID, Name: category.Name, Type: category.Type}
+}
+
+// newServeRun flattens a run, resolving its players and platform to names.
+func (p *proxyServer) newServeRun(place int, run Run, playerMap, platformMap map[string]string) ServeRun {
+	result := ServeRun{
+		Place:    place,
+		ID:       run.ID,
+		Platform: getPlatformName(run, platformMap),
+		Emulated: run.System.Emulated,
+		Date:     run.Date,
+		Comment:  run.Comment,
+		Weblink:  run.Weblink,
+		Times: ServeTimes{
+			Primary:         ptSeconds(run.Times.Primary),
+			Realtime:        ptSeconds(run.Times.Realtime),
+			RealtimeNoLoads: ptSeconds(run.Times.RealtimeNoLoads),
+			Ingame:          ptSeconds(run.Times.Ingame),
+		},
+	}
+	result.Time = result.Times.Primary
+	result.TimeText = formatSeconds(result.Time)
+	if len(run.Videos.Links) > 0 {
+		result.Video = run.Videos.Links[0].URI
+	}
+	
+	result.Players = runPlayerNames(p.api, run, playerMap)
+	return result
+}
+
+// runPlayerNames resolves a run's players to names. Players missing from
+// playerMap are looked up through the user cache.
+func runPlayerNames(api *SpeedrunAPI, run Run, playerMap map[string]string) []string {
+	names := []string{}
+	for _, player := range run.Players {
+		name := player.Name
+		switch {
+		case player.Rel == "guest":
+		case playerMap[player.ID] != "":
+			name = playerMap[player.ID]
+		default:
+			if user := api.GetUserData(player.ID); user != nil {
+				name = user.Names.International
+			} else {
+				name = player.ID
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,844 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Snapshots are offline copies of a game's API responses, one gzipped JSON
+// file per game under $XDG_DATA_HOME/speedrun-cli/snapshots. 'sync' fills
+// them by running the same requests browsing does, and --offline answers
+// every GET from them instead of the network. Searches and lookups by
+// name that were never requested verbatim are answered from the synced
+// games and runners.
+
+const SnapshotDirName = "snapshots"
+
+// Snapshot holds every response recorded while syncing one game.
+type Snapshot struct {
+	Game         SavedGame `json:"game"`
+	SyncedAt     time.Time `json:"synced_at"`
+	Leaderboards int       `json:"leaderboards"`
+	// SearchResult is the game as game searches return it, with categories
+	SearchResult json.RawMessage            `json:"search_result"`
+	Users        map[string]json.RawMessage `json:"users"`
+	Responses    map[string]json.RawMessage `json:"responses"`
+	
+	size int64
+}
+
+func dataDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, ConfigDirName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".local", "share", ConfigDirName)
+	}
+	return filepath.Join(home, ".local", "share", ConfigDirName)
+}
+
+func snapshotPath(gameID string) string {
+	return filepath.Join(dataDir(), SnapshotDirName, gameID+".json.gz")
+}
+
+func loadSnapshot(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+	var snapshot Snapshot
+	if err := json.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+	if info, err := file.Stat(); err == nil {
+		snapshot.size = info.Size()
+	}
+	return &snapshot, nil
+}
+
+// loadSnapshots reads every snapshot, oldest sync first. Unreadable files
+// are logged and skipped.
+func loadSnapshots() []*Snapshot {
+	paths, _ := filepath.Glob(filepath.Join(dataDir(), SnapshotDirName, "*.json.gz"))
+	
+	var snapshots []*Snapshot
+	for _, path := range paths {
+		snapshot, err := loadSnapshot(path)
+		if err != nil {
+			logger.Warn("skipping snapshot", "path", path, "error", err)
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].SyncedAt.Before(snapshots[j].SyncedAt) })
+	return snapshots
+}
+
+func saveSnapshot(snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	writer.Write(data)
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	
+	path := snapshotPath(snapshot.Game.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	snapshot.size = int64(buf.Len())
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// snapshotRecorder collects responses while a game syncs.
+type snapshotRecorder struct {
+	mu        sync.Mutex
+	responses map[string]json.RawMessage
+}
+
+func (r *snapshotRecorder) record(endpoint string, body []byte) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses[endpoint] = append(json.RawMessage(nil), body...)
+}
+
+// offlineStore answers GET requests from the loaded snapshots. It is
+// loaded once per process and never changes afterwards.
+type offlineStore struct {
+	snapshots []*Snapshot
+	byGame    map[string]*Snapshot
+	// users maps lowercase IDs and names to user objects
+	users map[string]json.RawMessage
+}
+
+var (
+	offlineOnce     sync.Once
+	offlineSnapshot *offlineStore
+)
+
+// offlineSnapshots loads the snapshots the first time offline mode needs
+// them. Later SpeedrunAPI instances share the same store.
+func offlineSnapshots() *offlineStore {
+	offlineOnce.Do(func() {
+		store := &offlineStore{
+			snapshots: loadSnapshots(),
+			byGame:    make(map[string]*Snapshot),
+			users:     make(map[string]json.RawMessage),
+		}
+		for _, snapshot := range store.snapshots {
+			store.byGame[snapshot.Game.ID] = snapshot
+			for id, raw := range snapshot.Users {
+				store.users[strings.ToLower(id)] = raw
+				var user User
+				if json.Unmarshal(raw, &user) == nil && user.Names.International != "" {
+					store.users[strings.ToLower(user.Names.International)] = raw
+				}
+			}
+		}
+		logger.Debug("loaded offline snapshots", "games", len(store.snapshots), "users", len(store.users))
+		offlineSnapshot = store
+	})
+	return offlineSnapshot
+}
+
+// get answers a GET endpoint, newest snapshot first, falling back to
+// searches and lookups built from the synced games and users.
+func (s *offlineStore) get(endpoint string) ([]byte, error) {
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		if body, ok := s.snapshots[i].Responses[endpoint]; ok {
+			return body, nil
+		}
+	}
+	
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	
+	switch {
+	case path == "/games" && query.Has("name"):
+		return s.searchGames(query.Get("name"))
+	case path == "/users" && query.Has("lookup"):
+		users := []json.RawMessage{}
+		if raw, ok := s.users[strings.ToLower(query.Get("lookup"))]; ok {
+			users = append(users, raw)
+		}
+		return json.Marshal(map[string]interface{}{"data": users})
+	case path == "/runs" && (query.Has("user") || query.Has("game")) && query.Get("status") != "new":
+		return s.boardRuns(query)
+	case segments[0] == "leaderboards":
+		if body, ok := s.leaderboard(path, query); ok {
+			return body, nil
+		}
+	case len(segments) == 3 && segments[0] == "users" && segments[2] == "personal-bests":
+		return s.personalBests(segments[1])
+	case len(segments) == 2 && segments[0] == "users":
+		if name, err := url.PathUnescape(segments[1]); err == nil {
+			if raw, ok := s.users[strings.ToLower(name)]; ok {
+				return json.Marshal(map[string]json.RawMessage{"data": raw})
+			}
+		}
+	case len(segments) >= 2 && segments[0] == "games":
+		// Abbreviations are case-insensitive, but only the synced spelling
+		// was recorded
+		for _, snapshot := range s.snapshots {
+			if strings.EqualFold(snapshot.Game.Abbreviation, segments[1]) && snapshot.Game.ID != segments[1] {
+				segments[1] = snapshot.Game.ID
+				return s.get("/" + strings.Join(segments, "/") + strings.TrimPrefix(endpoint, path))
+			}
+		}
+	}
+	
+	return nil, &APIError{
+		Message:    fmt.Sprintf("%s is not in the offline snapshots; run 'speedrun-cli sync <game>' while online", endpoint),
+		StatusCode: http.StatusNotFound,
+		URL:        endpoint,
+		Context:    "offline",
+	}
+}
+
+// searchGames matches synced games by name or abbreviation, the way game
+// searches do online.
+func (s *offlineStore) searchGames(name string) ([]byte, error) {
+	needle := strings.ToLower(name)
+	games := []json.RawMessage{}
+	for _, snapshot := range s.snapshots {
+		if strings.Contains(strings.ToLower(snapshot.Game.Name), needle) || strings.EqualFold(snapshot.Game.Abbreviation, name) {
+			games = append(games, snapshot.SearchResult)
+		}
+	}
+	return json.Marshal(map[string]interface{}{"data": games})
+}
+
+// boardEntry is a run on a synced leaderboard with its place.
+type boardEntry struct {
+	snapshot *Snapshot
+	place    int
+	run      Run
+	raw      json.RawMessage
+}
+
+// boardEntries lists every run on the synced leaderboards once. A run is
+// on its category's default board too, but its subcategory board has the
+// place the site shows, so that one wins.
+func (s *offlineStore) boardEntries() []boardEntry {
+	entries := make(map[string]boardEntry)
+	filtered := make(map[string]bool)
+	
+	for _, snapshot := range s.snapshots {
+		for endpoint, body := range snapshot.Responses {
+			// Full-game boards are recorded twice; the players embed has them all
+			if !strings.HasPrefix(endpoint, "/leaderboards/") ||
+				(!strings.Contains(endpoint, "embed=players") && !strings.Contains(endpoint, "/level/")) {
+				continue
+			}
+			var response struct {
+				Data struct {
+					Runs []struct {
+						Place int             `json:"place"`
+						Run   json.RawMessage `json:"run"`
+					} `json:"runs"`
+				} `json:"data"`
+			}
+			if json.Unmarshal(body, &response) != nil {
+				continue
+			}
+			
+			onSubcategory := strings.Contains(endpoint, "var-")
+			for _, entry := range response.Data.Runs {
+				var run Run
+				if json.Unmarshal(entry.Run, &run) != nil {
+					continue
+				}
+				if _, seen := entries[run.ID]; seen && (filtered[run.ID] || !onSubcategory) {
+					continue
+				}
+				entries[run.ID] = boardEntry{snapshot: snapshot, place: entry.Place, run: run, raw: entry.Run}
+				filtered[run.ID] = onSubcategory
+			}
+		}
+	}
+	
+	list := make([]boardEntry, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, entry)
+	}
+	return list
+}
+
+// snapshotResources holds a synced game's categories, levels, and
+// platforms by ID, ready to embed.
+type snapshotResources struct {
+	game       json.RawMessage
+	categories map[string]json.RawMessage
+	levels     map[string]json.RawMessage
+	platforms  map[string]json.RawMessage
+}
+
+func (snapshot *Snapshot) resources() snapshotResources {
+	resources := snapshotResources{
+		game:       snapshot.SearchResult,
+		categories: make(map[string]json.RawMessage),
+		levels:     make(map[string]json.RawMessage),
+		platforms:  make(map[string]json.RawMessage),
+	}
+	
+	var game struct {
+		Categories struct {
+			Data []json.RawMessage `json:"data"`
+		} `json:"categories"`
+	}
+	json.Unmarshal(snapshot.SearchResult, &game)
+	indexByID(game.Categories.Data, resources.categories)
+	
+	var levels struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	json.Unmarshal(snapshot.Responses[fmt.Sprintf("/games/%s/levels", snapshot.Game.ID)], &levels)
+	indexByID(levels.Data, resources.levels)
+	
+	var platforms struct {
+		Data struct {
+			Platforms struct {
+				Data []json.RawMessage `json:"data"`
+			} `json:"platforms"`
+		} `json:"data"`
+	}
+	json.Unmarshal(snapshot.Responses[fmt.Sprintf("/games/%s?embed=platforms", snapshot.Game.ID)], &platforms)
+	indexByID(platforms.Data.Platforms.Data, resources.platforms)
+	
+	return resources
+}
+
+func indexByID(objects []json.RawMessage, index map[string]json.RawMessage) {
+	for _, raw := range objects {
+		var object struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(raw, &object) == nil && object.ID != "" {
+			index[object.ID] = raw
+		}
+	}
+}
+
+// embedded wraps a resource the way embeds return it.
+func embedded(raw json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return nil
+	}
+	wrapped, _ := json.Marshal(map[string]json.RawMessage{"data": raw})
+	return wrapped
+}
+
+// boardRuns answers a /runs query from the runs on the synced leaderboards.
+// The user, game, category, and level filters, date or submission order,
+// paging, and the game and category embeds are supported. Only verified
+// runs that are still on a board can be found, so obsolete runs are missing.
+func (s *offlineStore) boardRuns(query url.Values) ([]byte, error) {
+	var matches []boardEntry
+	for _, entry := range s.boardEntries() {
+		if runMatchesQuery(entry.run, query) {
+			matches = append(matches, entry)
+		}
+	}
+	
+	bySubmission := query.Get("orderby") == "submitted"
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i].run, matches[j].run
+		if bySubmission && !a.Submitted.Equal(b.Submitted) {
+			return a.Submitted.After(b.Submitted)
+		}
+		if a.Date != b.Date {
+			return a.Date > b.Date
+		}
+		return a.ID > b.ID
+	})
+	
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	max, _ := strconv.Atoi(query.Get("max"))
+	matches = matches[min(offset, len(matches)):]
+	hasNext := max > 0 && len(matches) > max
+	if hasNext {
+		matches = matches[:max]
+	}
+	
+	embeds := make(map[string]bool)
+	for _, embed := range strings.Split(query.Get("embed"), ",") {
+		embeds[embed] = true
+	}
+	resources := make(map[*Snapshot]snapshotResources)
+	
+	data := make([]map[string]json.RawMessage, 0, len(matches))
+	for _, entry := range matches {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(entry.raw, &fields) != nil {
+			continue
+		}
+		if _, ok := resources[entry.snapshot]; !ok {
+			resources[entry.snapshot] = entry.snapshot.resources()
+		}
+		fields["place"], _ = json.Marshal(entry.place)
+		if embeds["game"] {
+			fields["game"] = embedded(resources[entry.snapshot].game)
+		}
+		if category := resources[entry.snapshot].categories[entry.run.Category]; embeds["category"] && category != nil {
+			fields["category"] = embedded(category)
+		}
+		data = append(data, fields)
+	}
+	
+	response := map[string]interface{}{"data": data}
+	if hasNext {
+		response["pagination"] = map[string]interface{}{"links": []map[string]string{{"rel": "next"}}}
+	}
+	return json.Marshal(response)
+}
+
+func runMatchesQuery(run Run, query url.Values) bool {
+	if query.Has("user") && !hasRunPlayer(run, query.Get("user")) {
+		return false
+	}
+	for key, value := range map[string]string{"game": run.Game, "category": run.Category, "level": run.Level} {
+		if query.Has(key) && query.Get(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// personalBests lists a runner's runs on the synced leaderboards, which are
+// their best on each board, with the game, category, level, and platform
+// embedded.
+func (s *offlineStore) personalBests(userID string) ([]byte, error) {
+	var pbs []boardEntry
+	for _, entry := range s.boardEntries() {
+		if hasRunPlayer(entry.run, userID) {
+			pbs = append(pbs, entry)
+		}
+	}
+	sort.Slice(pbs, func(i, j int) bool {
+		a, b := pbs[i].run, pbs[j].run
+		if a.Game != b.Game {
+			return a.Game < b.Game
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.ID < b.ID
+	})
+	
+	resources := make(map[*Snapshot]snapshotResources)
+	data := make([]map[string]json.RawMessage, 0, len(pbs))
+	for _, entry := range pbs {
+		if _, ok := resources[entry.snapshot]; !ok {
+			resources[entry.snapshot] = entry.snapshot.resources()
+		}
+		game := resources[entry.snapshot]
+		place, _ := json.Marshal(entry.place)
+		data = append(data, map[string]json.RawMessage{
+			"place":    place,
+			"run":      entry.raw,
+			"game":     embedded(game.game),
+			"category": embedded(game.categories[entry.run.Category]),
+			"level":    embedded(game.levels[entry.run.Level]),
+			"platform": embedded(game.platforms[entry.run.System.Platform]),
+		})
+	}
+	return json.Marshal(map[string]interface{}{"data": data})
+}
+
+// leaderboard answers a board request whose exact URL was not recorded
+// from a recorded request for the same board and filters that embedded at
+// least as much, since run placements ask without embeds.
+func (s *offlineStore) leaderboard(path string, query url.Values) ([]byte, bool) {
+	filters, embeds := splitEmbeds(query)
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		endpoints := make([]string, 0, len(s.snapshots[i].Responses))
+		for endpoint := range s.snapshots[i].Responses {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		
+		for _, endpoint := range endpoints {
+			recordedPath, rawQuery, _ := strings.Cut(endpoint, "?")
+			if recordedPath != path {
+				continue
+			}
+			recordedQuery, _ := url.ParseQuery(rawQuery)
+			recordedFilters, recordedEmbeds := splitEmbeds(recordedQuery)
+			if recordedFilters.Encode() != filters.Encode() {
+				continue
+			}
+			covered := true
+			for embed := range embeds {
+				covered = covered && recordedEmbeds[embed]
+			}
+			if covered {
+				return s.snapshots[i].Responses[endpoint], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func splitEmbeds(query url.Values) (url.Values, map[string]bool) {
+	filters := url.Values{}
+	embeds := make(map[string]bool)
+	for key, values := range query {
+		if key != "embed" {
+			filters[key] = values
+			continue
+		}
+		for _, value := range values {
+			for _, embed := range strings.Split(value, ",") {
+				if embed != "" {
+					embeds[embed] = true
+				}
+			}
+		}
+	}
+	return filters, embeds
+}
+
+func hasRunPlayer(run Run, userID string) bool {
+	for _, player := range run.Players {
+		if player.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// syncedAt reports when a game's snapshot was taken.
+func (s *offlineStore) syncedAt(gameID string) (time.Time, bool) {
+	if snapshot, ok := s.byGame[gameID]; ok {
+		return snapshot.SyncedAt, true
+	}
+	return time.Time{}, false
+}
+
+// showSnapshotAge notes how old the data for a game is in offline mode.
+func showSnapshotAge(api *SpeedrunAPI, gameID string) {
+	if api.offline == nil {
+		return
+	}
+	if syncedAt, ok := api.offline.syncedAt(gameID); ok {
+		fmt.Printf("%sOffline snapshot synced %s (%s)\n", icon("📦"), formatAge(syncedAt), syncedAt.Local().Format("2006-01-02 15:04"))
+	}
+}
+
+func handleSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	list := fs.Bool("list", false, "list synced games and their age")
+	remove := fs.Bool("remove", false, "delete the snapshots of the given games")
+	all := fs.Bool("all", false, "re-sync every game that has a snapshot")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli sync <game>...")
+		fmt.Fprintln(fs.Output(), "       speedrun-cli sync -all | -list | -remove <game>...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	if *list {
+		listSnapshots(loadSnapshots())
+		return
+	}
+	
+	if *remove {
+		if fs.NArg() == 0 {
+			fs.Usage()
+			os.Exit(1)
+		}
+		removeSnapshots(loadSnapshots(), fs.Args())
+		return
+	}
+	
+	games := fs.Args()
+	if *all {
+		for _, snapshot := range loadSnapshots() {
+			games = append(games, snapshot.Game.ID)
+		}
+	}
+	if len(games) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	
+	if settings.Bool("offline") {
+		fmt.Println("Error: sync needs the network; run it without --offline")
+		os.Exit(1)
+	}
+	
+	// Only uncached responses are recorded (see makeRequest)
+	api := NewSpeedrunAPI()
+	api.cache = nil
+	
+	failed := 0
+	for _, name := range games {
+		fmt.Printf("%sSyncing %s...\n", icon("🔄"), name)
+		snapshot, err := syncGame(api, name)
+		if err == nil {
+			err = saveSnapshot(snapshot)
+		}
+		if err != nil {
+			fmt.Printf("Error syncing %s: %v\n", name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s%s: %d leaderboards, %d runners (%s)\n", icon("✅"), snapshot.Game.Name,
+			snapshot.Leaderboards, len(snapshot.Users), formatBytes(snapshot.size))
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// syncGame records what browsing a game requests: the game itself, its
+// overview, categories, levels, platforms, regions, each category's
+// variables, a leaderboard with runner names for every full-game
+// subcategory, and every level's board. Personal bests and run lists are
+// built from these boards offline rather than synced per runner.
+func syncGame(api *SpeedrunAPI, name string) (*Snapshot, error) {
+	recorder := &snapshotRecorder{responses: make(map[string]json.RawMessage)}
+	api.recorder = recorder
+	defer func() { api.recorder = nil }()
+	
+	game, err := api.GetGame(name)
+	if err != nil {
+		games, searchErr := api.SearchGames(name)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+		if game = matchGame(games, name); game == nil {
+			return nil, err
+		}
+	}
+	
+	api.GetGame(game.ID)
+	api.GetGame(game.Abbreviation)
+	if _, err := api.SearchGames(game.Names.International); err != nil {
+		return nil, err
+	}
+	if _, err := api.GetGameOverview(game.ID); err != nil {
+		return nil, err
+	}
+	categories, err := api.GetGameCategories(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, load := range []func(string) error{
+		func(id string) error { _, err := api.GetGamePlatforms(id); return err },
+		func(id string) error { _, err := api.GetGameRegions(id); return err },
+	} {
+		if err := load(game.ID); err != nil {
+			logger.Warn("sync skipped game data", "game", game.ID, "error", err)
+		}
+	}
+	levels, err := api.GetGameLevels(game.ID)
+	if err != nil {
+		logger.Warn("sync skipped game data", "game", game.ID, "error", err)
+	}
+	
+	snapshot := &Snapshot{
+		Game:     newSavedGame(game),
+		SyncedAt: time.Now().UTC(),
+		Users:    make(map[string]json.RawMessage),
+	}
+	
+	for _, category := range categories {
+		if category.Type != "per-game" {
+			continue
+		}
+		subcategories, err := api.GetCategoryVariables(category.ID)
+		if err != nil {
+			return nil, err
+		}
+		
+		valueIDs := []string{""}
+		for _, subcategory := range subcategories {
+			valueIDs = append(valueIDs, subcategory.ID)
+		}
+		for _, valueID := range valueIDs {
+			showProgress("⏳", "Syncing %s leaderboards (%d so far)...", category.Name, snapshot.Leaderboards)
+			lb, err := api.GetLeaderboard(game.ID, category.ID, "", valueID, "")
+			if err == nil {
+				err = api.LoadPlayerNames(lb)
+			}
+			clearProgress()
+			if err != nil {
+				return nil, fmt.Errorf("%s leaderboard: %w", category.Name, err)
+			}
+			snapshot.Leaderboards++
+		}
+	}
+	
+	for _, category := range categories {
+		if category.Type == "per-level" && len(levels) > 0 {
+			syncLevelBoards(api, game, category, levels, snapshot)
+		}
+	}
+	
+	snapshot.Responses = recorder.responses
+	if err := collectSnapshotUsers(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// syncLevelBoards records a per-level category's board for every level and
+// subcategory value, requested the way run placements request them. A
+// board that fails to load is logged and skipped.
+func syncLevelBoards(api *SpeedrunAPI, game *Game, category Category, levels []Level, snapshot *Snapshot) {
+	variables, err := api.GetVariables(category.ID)
+	if err != nil {
+		logger.Warn("sync skipped level boards", "category", category.ID, "error", err)
+		return
+	}
+	
+	for _, level := range levels {
+		subcategoryVars := make(map[string]bool)
+		values := []map[string]string{nil}
+		for _, variable := range variables {
+			if !variable.IsSubcategory || variable.Scope.Type == "full-game" ||
+				(variable.Scope.Type == "single-level" && variable.Scope.Level != level.ID) {
+				continue
+			}
+			subcategoryVars[variable.ID] = true
+			for valueID := range variable.Values.Values {
+				values = append(values, map[string]string{variable.ID: valueID})
+			}
+		}
+		
+		for _, value := range values {
+			showProgress("⏳", "Syncing %s leaderboards (%d so far)...", level.Name, snapshot.Leaderboards)
+			run := Run{Game: game.ID, Category: category.ID, Level: level.ID, Values: value}
+			_, err := api.GetRunLeaderboard(run, subcategoryVars)
+			clearProgress()
+			if err != nil {
+				logger.Warn("sync skipped level board", "level", level.ID, "category", category.ID, "error", err)
+				continue
+			}
+			snapshot.Leaderboards++
+		}
+	}
+}
+
+// collectSnapshotUsers keeps the runners from the players embeds and the
+// game as game searches return it, so both can be looked up by name
+// offline.
+func collectSnapshotUsers(snapshot *Snapshot) error {
+	for endpoint, body := range snapshot.Responses {
+		if strings.HasPrefix(endpoint, "/games?") {
+			var response struct {
+				Data []json.RawMessage `json:"data"`
+			}
+			if json.Unmarshal(body, &response) != nil {
+				continue
+			}
+			for _, raw := range response.Data {
+				var game Game
+				if json.Unmarshal(raw, &game) == nil && game.ID == snapshot.Game.ID {
+					snapshot.SearchResult = raw
+				}
+			}
+			continue
+		}
+		
+		if !strings.HasPrefix(endpoint, "/leaderboards/") {
+			continue
+		}
+		var response struct {
+			Data struct {
+				Players struct {
+					Data []json.RawMessage `json:"data"`
+				} `json:"players"`
+			} `json:"data"`
+		}
+		if json.Unmarshal(body, &response) != nil {
+			continue
+		}
+		for _, raw := range response.Data.Players.Data {
+			var player User
+			if json.Unmarshal(raw, &player) == nil && player.ID != "" {
+				snapshot.Users[player.ID] = raw
+			}
+		}
+	}
+	
+	if snapshot.SearchResult == nil {
+		return fmt.Errorf("searching for %s did not return the game", snapshot.Game.Name)
+	}
+	return nil
+}
+
+func listSnapshots(snapshots []*Snapshot) {
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots yet. Run 'speedrun-cli sync <game>' to take one.")
+		return
+	}
+	
+	nameWidth := len("GAME")
+	for _, snapshot := range snapshots {
+		nameWidth = max(nameWidth, len(snapshot.Game.Name))
+	}
+	fmt.Printf("%-*s  %-10s  %-10s  %12s  %8s\n", nameWidth, "GAME", "ID", "SYNCED", "LEADERBOARDS", "SIZE")
+	for _, snapshot := range snapshots {
+		fmt.Printf("%-*s  %-10s  %-10s  %12d  %8s\n", nameWidth, snapshot.Game.Name, snapshot.Game.ID,
+			formatAge(snapshot.SyncedAt), snapshot.Leaderboards, formatBytes(snapshot.size))
+	}
+}
+
+func removeSnapshots(snapshots []*Snapshot, games []string) {
+	failed := false
+	for _, name := range games {
+		var match *Snapshot
+		for _, snapshot := range snapshots {
+			if snapshot.Game.ID == name || strings.EqualFold(snapshot.Game.Abbreviation, name) || strings.EqualFold(snapshot.Game.Name, name) {
+				match = snapshot
+			}
+		}
+		if match == nil {
+			fmt.Printf("Error: no snapshot for %q\n", name)
+			failed = true
+			continue
+		}
+		if err := os.Remove(snapshotPath(match.Game.ID)); err != nil {
+			fmt.Printf("Error removing snapshot for %s: %v\n", match.Game.Name, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("Removed snapshot for %s\n", match.Game.Name)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,646 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type submitOptions struct {
+	Game            string
+	Category        string
+	Level           string
+	Date            string
+	Region          string
+	Platform        string
+	Emulated        bool
+	Realtime        string
+	RealtimeNoLoads string
+	Ingame          string
+	Video           string
+	Comment         string
+	Variables       map[string]string
+}
+
+// variableFlag collects repeated --var name=value flags.
+type variableFlag map[string]string
+
+func (v variableFlag) String() string {
+	pairs := make([]string, 0, len(v))
+	for name, value := range v {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (v variableFlag) Set(pair string) error {
+	name, value, ok := strings.Cut(pair, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("expected name=value, got %q", pair)
+	}
+	v[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	return nil
+}
+
+func handleSubmit(api *SpeedrunAPI, args []string) {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	file := fs.String("file", "", "read the submission from a YAML file")
+	dryRun := fs.Bool("dry-run", false, "print the JSON payload without submitting")
+	flagOpts := submitOptions{Variables: make(map[string]string)}
+	fs.StringVar(&flagOpts.Category, "category", "", "category name or ID")
+	fs.StringVar(&flagOpts.Level, "level", "", "level name or ID (per-level categories)")
+	fs.StringVar(&flagOpts.Date, "date", "", "date the run was played (YYYY-MM-DD, default today)")
+	fs.StringVar(&flagOpts.Region, "region", "", "region name or ID")
+	fs.StringVar(&flagOpts.Platform, "platform", "", "platform name or ID")
+	fs.BoolVar(&flagOpts.Emulated, "emulated", false, "the run was played on an emulator")
+	fs.StringVar(&flagOpts.Realtime, "realtime", "", "real time (e.g. 1:23:45.678)")
+	fs.StringVar(&flagOpts.RealtimeNoLoads, "realtime-noloads", "", "real time without loads")
+	fs.StringVar(&flagOpts.Ingame, "ingame", "", "in-game time")
+	fs.StringVar(&flagOpts.Video, "video", "", "video URL")
+	fs.StringVar(&flagOpts.Comment, "comment", "", "run comment")
+	fs.Var(variableFlag(flagOpts.Variables), "var", "variable value as name=value (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: speedrun-cli submit [flags] <game>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	
+	opts := submitOptions{Variables: make(map[string]string)}
+	if *file != "" {
+		if err := loadSubmitFile(*file, &opts); err != nil {
+			fmt.Printf("Error reading %s: %v\n", *file, err)
+			os.Exit(1)
+		}
+	}
+	
+	// Flags take precedence over the file
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "category":
+			opts.Category = flagOpts.Category
+		case "level":
+			opts.Level = flagOpts.Level
+		case "date":
+			opts.Date = flagOpts.Date
+		case "region":
+			opts.Region = flagOpts.Region
+		case "platform":
+			opts.Platform = flagOpts.Platform
+		case "emulated":
+			opts.Emulated = flagOpts.Emulated
+		case "realtime":
+			opts.Realtime = flagOpts.Realtime
+		case "realtime-noloads":
+			opts.RealtimeNoLoads = flagOpts.RealtimeNoLoads
+		case "ingame":
+			opts.Ingame = flagOpts.Ingame
+		case "video":
+			opts.Video = flagOpts.Video
+		case "comment":
+			opts.Comment = flagOpts.Comment
+		}
+	})
+	for name, value := range flagOpts.Variables {
+		opts.Variables[name] = value
+	}
+	if fs.NArg() > 0 {
+		opts.Game = strings.Join(fs.Args(), " ")
+	}
+	
+	interactive := *file == "" && opts.Category == ""
+	
+	if opts.Game == "" {
+		if !interactive {
+			fmt.Println("Error: a game is required")
+			fs.Usage()
+			os.Exit(1)
+		}
+		opts.Game = getUserInput("Enter game name: ")
+	}
+	
+	games, err := api.SearchGames(opts.Game)
+	if err != nil {
+		fmt.Printf("Error searching games: %v\n", err)
+		os.Exit(1)
+	}
+	
+	game := matchGame(games, opts.Game)
+	if game == nil {
+		os.Exit(1)
+	}
+	
+	submission, err := buildSubmission(api, game, opts, interactive)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	
+	payload, err := json.MarshalIndent(map[string]*RunSubmission{"run": submission}, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding submission: %v\n", err)
+		os.Exit(1)
+	}
+	
+	if *dryRun {
+		fmt.Println(string(payload))
+		return
+	}
+	
+	if !api.HasAPIKey() {
+		fmt.Printf("Error: submitting runs requires an API key (set %s or run 'speedrun-cli login')\n", APIKeyEnvVar)
+		os.Exit(1)
+	}
+	
+	if interactive {
+		fmt.Printf("\n%s\n", payload)
+		if !confirm("\nSubmit this run? [y/N]: ") {
+			fmt.Println("Cancelled.")
+			return
+		}
+	}
+	
+	run, err := api.SubmitRun(payload)
+	if err != nil {
+		fmt.Printf("Error submitting run: %v\n", err)
+		os.Exit(1)
+	}
+	
+	fmt.Printf("%sRun submitted and awaiting verification: %s\n", icon("✅"), run.Weblink)
+}
+
+// matchGame prefers an exact abbreviation or name match over asking the user.
+func matchGame(games []Game, query string) *Game {
+	for i, game := range games {
+		if strings.EqualFold(game.Abbreviation, query) || strings.EqualFold(game.Names.International, query) {
+			return &games[i]
+		}
+	}
+	return selectGame(games)
+}
+
+// buildSubmission resolves names to IDs against the game's categories,
+// levels, variables, platforms, and regions, prompting for anything
+// missing in interactive mode and reporting every problem otherwise.
+func buildSubmission(api *SpeedrunAPI, game *Game, opts submitOptions, interactive bool) (*RunSubmission, error) {
+	var problems []string
+	submission := &RunSubmission{
+		Emulated:  opts.Emulated,
+		Variables: make(map[string]SubmissionVariable),
+	}
+	
+	categories, err := api.GetGameCategories(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	var category *Category
+	if opts.Category != "" {
+		for i := range categories {
+			if categories[i].ID == opts.Category || strings.EqualFold(categories[i].Name, opts.Category) {
+				category = &categories[i]
+				break
+			}
+		}
+		if category == nil {
+			return nil, fmt.Errorf("unknown category %q (available: %s)", opts.Category, strings.Join(categoryNames(categories), ", "))
+		}
+	} else {
+		category = selectCategory(categories)
+		if category == nil || category.ID == "BACK" {
+			return nil, fmt.Errorf("no category selected")
+		}
+	}
+	submission.Category = category.ID
+	
+	if category.Type == "per-level" {
+		levels, err := api.GetGameLevels(game.ID)
+		if err != nil {
+			return nil, err
+		}
+		
+		levelNames := make([]string, len(levels))
+		for i, level := range levels {
+			levelNames[i] = level.Name
+			if level.ID == opts.Level || strings.EqualFold(level.Name, opts.Level) {
+				submission.Level = level.ID
+			}
+		}
+		
+		if submission.Level == "" {
+			if opts.Level != "" {
+				problems = append(problems, fmt.Sprintf("unknown level %q", opts.Level))
+			} else if interactive {
+				if idx := selectOption("Levels", levelNames, false); idx >= 0 {
+					submission.Level = levels[idx].ID
+				}
+			}
+			if submission.Level == "" && opts.Level == "" {
+				problems = append(problems, fmt.Sprintf("category %s is per-level and needs a level", category.Name))
+			}
+		}
+	} else if opts.Level != "" {
+		problems = append(problems, fmt.Sprintf("category %s is full-game and does not take a level", category.Name))
+	}
+	
+	variables, err := api.GetVariables(category.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	used := make(map[string]bool)
+	for _, variable := range variables {
+		if !variableApplies(variable, submission.Level) {
+			continue
+		}
+		
+		input, key := lookupVariableInput(opts.Variables, variable)
+		if key != "" {
+			used[key] = true
+		}
+		
+		valueIDs := sortedValueIDs(variable)
+		
+		if input == "" && interactive {
+			labels := make([]string, len(valueIDs))
+			for i, valueID := range valueIDs {
+				labels[i] = variable.Values.Values[valueID].Label
+			}
+			// User-defined variables take any text, so offer the
+			// predefined values as suggestions instead of a menu
+			if variable.UserDefined {
+				input = getUserInput(userDefinedPrompt(variable, labels))
+			} else if idx := selectOption(variable.Name, labels, !variable.Mandatory); idx >= 0 {
+				input = valueIDs[idx]
+			}
+		}
+		
+		if input == "" {
+			if variable.Mandatory {
+				problems = append(problems, fmt.Sprintf("variable %q is mandatory", variable.Name))
+			}
+			continue
+		}
+		
+		valueID := ""
+		for _, id := range valueIDs {
+			if id == input || strings.EqualFold(variable.Values.Values[id].Label, input) {
+				valueID = id
+				break
+			}
+		}
+		
+		switch {
+		case valueID != "":
+			submission.Variables[variable.ID] = SubmissionVariable{Type: "pre-defined", Value: valueID}
+		case variable.UserDefined:
+			submission.Variables[variable.ID] = SubmissionVariable{Type: "user-defined", Value: input}
+		default:
+			problems = append(problems, fmt.Sprintf("unknown value %q for variable %q", input, variable.Name))
+		}
+	}
+	
+	for name := range opts.Variables {
+		if !used[name] {
+			problems = append(problems, fmt.Sprintf("variable %q does not apply to %s", name, category.Name))
+		}
+	}
+	
+	platforms, err := api.GetGamePlatforms(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	platformNames := make([]string, len(platforms))
+	for i, platform := range platforms {
+		platformNames[i] = platform.Name
+		if platform.ID == opts.Platform || strings.EqualFold(platform.Name, opts.Platform) {
+			submission.Platform = platform.ID
+		}
+	}
+	
+	if submission.Platform == "" {
+		if opts.Platform != "" {
+			problems = append(problems, fmt.Sprintf("unknown platform %q (available: %s)", opts.Platform, strings.Join(platformNames, ", ")))
+		} else if len(platforms) > 0 {
+			if interactive {
+				if idx := selectOption("Platforms", platformNames, false); idx >= 0 {
+					submission.Platform = platforms[idx].ID
+				}
+			}
+			if submission.Platform == "" {
+				problems = append(problems, "a platform is required")
+			}
+		}
+	}
+	
+	regions, err := api.GetGameRegions(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	regionNames := make([]string, len(regions))
+	for i, region := range regions {
+		regionNames[i] = region.Name
+		if region.ID == opts.Region || strings.EqualFold(region.Name, opts.Region) {
+			submission.Region = region.ID
+		}
+	}
+	
+	if submission.Region == "" {
+		if opts.Region != "" {
+			problems = append(problems, fmt.Sprintf("unknown region %q (available: %s)", opts.Region, strings.Join(regionNames, ", ")))
+		} else if interactive && len(regions) > 0 {
+			if idx := selectOption("Regions", regionNames, true); idx >= 0 {
+				submission.Region = regions[idx].ID
+			}
+		}
+	}
+	
+	if interactive {
+		if !submission.Emulated {
+			submission.Emulated = confirm("Played on an emulator? [y/N]: ")
+		}
+		promptIfEmpty(&opts.Date, fmt.Sprintf("Date played (YYYY-MM-DD, Enter for %s): ", time.Now().Format("2006-01-02")))
+		if opts.Realtime == "" && opts.RealtimeNoLoads == "" && opts.Ingame == "" {
+			opts.Realtime = getUserInput("Real time (e.g. 1:23:45.678, Enter to skip): ")
+			opts.RealtimeNoLoads = getUserInput("Real time without loads (Enter to skip): ")
+			opts.Ingame = getUserInput("In-game time (Enter to skip): ")
+		}
+		promptIfEmpty(&opts.Video, "Video URL: ")
+		promptIfEmpty(&opts.Comment, "Comment (optional): ")
+	}
+	
+	submission.Date = opts.Date
+	if submission.Date == "" {
+		submission.Date = time.Now().Format("2006-01-02")
+	}
+	if played, err := time.Parse("2006-01-02", submission.Date); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid date %q (expected YYYY-MM-DD)", submission.Date))
+	} else if played.After(time.Now()) {
+		problems = append(problems, fmt.Sprintf("date %s is in the future", submission.Date))
+	}
+	
+	timeInputs := []struct {
+		name   string
+		input  string
+		target *float64
+	}{
+		{"realtime", opts.Realtime, &submission.Times.Realtime},
+		{"realtime-noloads", opts.RealtimeNoLoads, &submission.Times.RealtimeNoLoads},
+		{"ingame", opts.Ingame, &submission.Times.Ingame},
+	}
+	hasTime := false
+	for _, t := range timeInputs {
+		if t.input == "" {
+			continue
+		}
+		seconds, err := parseTimeInput(t.input)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", t.name, err))
+			continue
+		}
+		*t.target = seconds
+		hasTime = true
+	}
+	if !hasTime {
+		problems = append(problems, "at least one time (realtime, realtime-noloads, or ingame) is required")
+	}
+	
+	if opts.Video != "" {
+		if u, err := url.Parse(opts.Video); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("invalid video URL %q", opts.Video))
+		}
+	}
+	submission.Video = opts.Video
+	submission.Comment = opts.Comment
+	
+	if len(problems) > 0 {
+		bullet := "\n  " + symbol("•", "*") + " "
+		return nil, fmt.Errorf("invalid submission:%s%s", bullet, strings.Join(problems, bullet))
+	}
+	
+	if len(submission.Variables) == 0 {
+		submission.Variables = nil
+	}
+	return submission, nil
+}
+
+// userDefinedPrompt asks for a free-text variable value, listing any
+// predefined values.
+func userDefinedPrompt(variable Variable, labels []string) string {
+	prompt := variable.Name
+	if len(labels) > 0 {
+		prompt += fmt.Sprintf(" (e.g. %s, or any value)", strings.Join(labels, ", "))
+	}
+	if !variable.Mandatory {
+		prompt += ", Enter to skip"
+	}
+	return prompt + ": "
+}
+
+func promptIfEmpty(value *string, prompt string) {
+	if *value == "" {
+		*value = getUserInput(prompt)
+	}
+}
+
+func categoryNames(categories []Category) []string {
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+	return names
+}
+
+// variableApplies reports whether a variable's scope covers a full-game run
+// (levelID == "") or a run of the given level.
+func variableApplies(variable Variable, levelID string) bool {
+	switch variable.Scope.Type {
+	case "full-game":
+		return levelID == ""
+	case "all-levels":
+		return levelID != ""
+	case "single-level":
+		return levelID != "" && variable.Scope.Level == levelID
+	default:
+		return true
+	}
+}
+
+func lookupVariableInput(inputs map[string]string, variable Variable) (string, string) {
+	for name, value := range inputs {
+		if name == variable.ID || strings.EqualFold(name, variable.Name) {
+			return value, name
+		}
+	}
+	return "", ""
+}
+
+func sortedValueIDs(variable Variable) []string {
+	ids := make([]string, 0, len(variable.Values.Values))
+	for id := range variable.Values.Values {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return variable.Values.Values[ids[i]].Label < variable.Values.Values[ids[j]].Label
+	})
+	return ids
+}
+
+func loadSubmitFile(path string, opts *submitOptions) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	
+	values, sections, err := parseSimpleYAML(string(data))
+	if err != nil {
+		return err
+	}
+	
+	for key, value := range values {
+		switch key {
+		case "game":
+			opts.Game = value
+		case "category":
+			opts.Category = value
+		case "level":
+			opts.Level = value
+		case "date":
+			opts.Date = value
+		case "region":
+			opts.Region = value
+		case "platform":
+			opts.Platform = value
+		case "emulated":
+			emulated, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("emulated: expected true or false, got %q", value)
+			}
+			opts.Emulated = emulated
+		case "video":
+			opts.Video = value
+		case "comment":
+			opts.Comment = value
+		default:
+			return fmt.Errorf("unknown key %q", key)
+		}
+	}
+	
+	for section, entries := range sections {
+		switch section {
+		case "times":
+			for method, value := range entries {
+				switch method {
+				case "realtime":
+					opts.Realtime = value
+				case "realtime_noloads":
+					opts.RealtimeNoLoads = value
+				case "ingame":
+					opts.Ingame = value
+				default:
+					return fmt.Errorf("unknown timing method %q", method)
+				}
+			}
+		case "variables":
+			for name, value := range entries {
+				opts.Variables[name] = value
+			}
+		default:
+			return fmt.Errorf("unknown section %q", section)
+		}
+	}
+	
+	return nil
+}
+
+// parseSimpleYAML reads the YAML subset used by submission files: scalar
+// "key: value" pairs plus one level of indented "key: value" pairs under a
+// section header such as "times:" or "variables:".
+func parseSimpleYAML(data string) (map[string]string, map[string]map[string]string, error) {
+	values := make(map[string]string)
+	sections := make(map[string]map[string]string)
+	section := ""
+	
+	for lineNum, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, nil, fmt.Errorf("line %d: expected \"key: value\"", lineNum+1)
+		}
+		key = strings.TrimSpace(key)
+		value = unquoteYAML(stripYAMLComment(strings.TrimSpace(value)))
+		
+		indented := line[0] == ' ' || line[0] == '\t'
+		switch {
+		case indented && section != "":
+			sections[section][key] = value
+		case indented:
+			return nil, nil, fmt.Errorf("line %d: unexpected indentation", lineNum+1)
+		case value == "":
+			section = key
+			sections[section] = make(map[string]string)
+		default:
+			section = ""
+			values[key] = value
+		}
+	}
+	
+	return values, sections, nil
+}
+
+// stripYAMLComment drops a trailing "# comment" from a value, so a
+// section header like "variables:  # per category" has an empty value.
+// A # inside quotes or without a space before it is kept.
+func stripYAMLComment(value string) string {
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+	
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		quote := value[0]
+		for i := 1; i < len(value); i++ {
+			switch {
+			case quote == '"' && value[i] == '\\':
+				i++
+			case quote == '\'' && value[i] == '\'' && i+1 < len(value) && value[i+1] == '\'':
+				i++
+			case value[i] == quote:
+				return value[:i+1]
+			}
+		}
+		return value
+	}
+	
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return value
+}
+
+func unquoteYAML(value string) string {
+	if len(value) >= 2 {
+		if value[0] == '"' && value[len(value)-1] == '"' {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				return unquoted
+			}
+		}
+		if value[0] == '\'' && value[len(value)-1] == '\'' {
+			return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+	}
+	return value
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSimpleYAML(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantValues   map[string]string
+		wantSections map[string]map[string]string
+		wantErr      bool
+	}{
+		{
+			name:         "scalars",
+			input:        "game: sm64\ncategory: 120 Star\n",
+			wantValues:   map[string]string{"game": "sm64", "category": "120 Star"},
+			wantSections: map[string]map[string]string{},
+		},
+		{
+			name:         "comments and document marker",
+			input:        "---\n# a submission\ngame: sm64  # the game\nvideo: https://youtu.be/x#t=10\n",
+			wantValues:   map[string]string{"game": "sm64", "video": "https://youtu.be/x#t=10"},
+			wantSections: map[string]map[string]string{},
+		},
+		{
+			name:         "quoted values keep #",
+			input:        "comment: \"sub 1:40 # finally\"  # note\nname: 'it''s #1'\n",
+			wantValues:   map[string]string{"comment": "sub 1:40 # finally", "name": "it's #1"},
+			wantSections: map[string]map[string]string{},
+		},
+		{
+			name:       "sections",
+			input:      "times:\n  realtime: 1:39:51\n  ingame: 1:38:00\nvariables:  # per category\n\tRegion: JP\nplatform: N64\n",
+			wantValues: map[string]string{"platform": "N64"},
+			wantSections: map[string]map[string]string{
+				"times":     {"realtime": "1:39:51", "ingame": "1:38:00"},
+				"variables": {"Region": "JP"},
+			},
+		},
+		{
+			name:    "missing colon",
+			input:   "game sm64\n",
+			wantErr: true,
+		},
+		{
+			name:    "indentation outside a section",
+			input:   "  game: sm64\n",
+			wantErr: true,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, sections, err := parseSimpleYAML(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSimpleYAML() = %v, %v, want an error", values, sections)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSimpleYAML() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", values, tt.wantValues)
+			}
+			if !reflect.DeepEqual(sections, tt.wantSections) {
+				t.Errorf("sections = %v, want %v", sections, tt.wantSections)
+			}
+		})
+	}
+}
+
+// TestBuildSubmissionFromFile runs submission files through the same path
+// as "submit --file --dry-run": the file is loaded, names are resolved
+// against the game's categories, variables, platforms, and regions on the
+// fake server, and the payload is encoded.
+func TestBuildSubmissionFromFile(t *testing.T) {
+	api, server := startFakeAPI(t, fakeFaults{})
+	
+	// A free-text variable with one suggested value, like a seed or a
+	// controller type
+	server.data.Variables = append(server.data.Variables, fakeObject{
+		"id": "seedvar1", "game": "o1y9wo6q", "name": "Seed", "category": "wkpoo02r",
+		"scope": fakeObject{"type": "full-game"}, "mandatory": false, "user-defined": true,
+		"values": fakeObject{"values": fakeObject{"seedval1": fakeObject{"label": "Default"}}},
+	})
+	
+	game, err := api.GetGame("sm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	tests := []struct {
+		name        string
+		file        string
+		wantPayload string
+		wantErrs    []string
+	}{
+		{
+			name: "names resolve to IDs",
+			file: `# 120 Star PB
+game: sm64
+category: 120 star
+date: 2024-03-01
+platform: Nintendo 64
+region: "JPN / NTSC"
+video: https://youtu.be/pb#t=5  # the run starts at 5s
+comment: "sub 1:40 # finally"
+times:
+  realtime: 1:39:50.5
+variables:
+  Version: JP
+  Seed: 1234
+`,
+			wantPayload: `{"run":{"category":"wkpoo02r","date":"2024-03-01","region":"o316x197","platform":"w89rwelk",` +
+				`"verified":false,"times":{"realtime":5990.5},"emulated":false,"video":"https://youtu.be/pb#t=5",` +
+				`"comment":"sub 1:40 # finally","variables":{"e8m7em86":{"type":"pre-defined","value":"9qj7z0oq"},` +
+				`"seedvar1":{"type":"user-defined","value":"1234"}}}}`,
+		},
+		{
+			name: "IDs and suggested values",
+			file: `category: wkpoo02r
+date: 2024-03-01
+platform: w89rwelk
+emulated: true
+times:
+  ingame: 1:38:00
+variables:
+  e8m7em86: jq6540ol
+  Seed: default
+`,
+			wantPayload: `{"run":{"category":"wkpoo02r","date":"2024-03-01","platform":"w89rwelk","verified":false,` +
+				`"times":{"ingame":5880},"emulated":true,"variables":{"e8m7em86":{"type":"pre-defined","value":"jq6540ol"},` +
+				`"seedvar1":{"type":"pre-defined","value":"seedval1"}}}}`,
+		},
+		{
+			name: "level run",
+			file: `category: Stage RTA
+level: Bob-omb Battlefield
+date: 2024-03-01
+platform: Nintendo 64
+times:
+  realtime: 9.8
+`,
+			wantPayload: `{"run":{"category":"xk9gx4gd","level":"5wkjv5d3","date":"2024-03-01","platform":"w89rwelk",` +
+				`"verified":false,"times":{"realtime":9.8},"emulated":false}}`,
+		},
+		{
+			name: "every problem is reported",
+			file: `category: 120 Star
+level: Bob-omb Battlefield
+date: 2999-01-01
+platform: Dreamcast
+video: not a url
+times:
+  realtime: 1:2:3:4
+variables:
+  Version: PAL
+  Players: 2
+`,
+			wantErrs: []string{
+				"category 120 Star is full-game and does not take a level",
+				`unknown value "PAL" for variable "Version"`,
+				`variable "Players" does not apply to 120 Star`,
+				`unknown platform "Dreamcast"`,
+				"date 2999-01-01 is in the future",
+				`realtime: invalid time "1:2:3:4"`,
+				"at least one time",
+				`invalid video URL "not a url"`,
+			},
+		},
+		{
+			name: "per-level category without a level",
+			file: `category: Stage RTA
+platform: Nintendo 64
+times:
+  realtime: 9.8
+`,
+			wantErrs: []string{"category Stage RTA is per-level and needs a level"},
+		},
+		{
+			name: "mandatory variable",
+			file: `category: 70 Star
+platform: Nintendo 64
+times:
+  realtime: 50:00
+`,
+			wantErrs: []string{`variable "Version" is mandatory`},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "run.yaml")
+			if err := os.WriteFile(path, []byte(tt.file), 0644); err != nil {
+				t.Fatal(err)
+			}
+			opts := submitOptions{Variables: make(map[string]string)}
+			if err := loadSubmitFile(path, &opts); err != nil {
+				t.Fatalf("loadSubmitFile() returned error: %v", err)
+			}
+			
+			submission, err := buildSubmission(api, game, opts, false)
+			if tt.wantErrs != nil {
+				if err == nil {
+					t.Fatalf("buildSubmission() succeeded, want errors %q", tt.wantErrs)
+				}
+				for _, want := range tt.wantErrs {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not mention %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildSubmission() returned error: %v", err)
+			}
+			
+			payload, err := json.Marshal(map[string]*RunSubmission{"run": submission})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(payload) != tt.wantPayload {
+				t.Errorf("payload\n%s\nwant\n%s", payload, tt.wantPayload)
+			}
+		})
+	}
+}
+
+func TestBuildSubmissionDefaultsDateToToday(t *testing.T) {
+	api, _ := startFakeAPI(t, fakeFaults{})
+	game, err := api.GetGame("sm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	opts := submitOptions{Category: "0 Star", Platform: "Nintendo 64", Realtime: "6:40", Variables: map[string]string{"Version": "US"}}
+	submission, err := buildSubmission(api, game, opts, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if today := time.Now().Format("2006-01-02"); submission.Date != today {
+		t.Errorf("date = %q, want today (%s)", submission.Date, today)
+	}
+}
+
+func TestLoadSubmitFileRejectsUnknownKeys(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{file: "categroy: 120 Star\n", want: `unknown key "categroy"`},
+		{file: "times:\n  rta: 1:40:00\n", want: `unknown timing method "rta"`},
+		{file: "splits:\n  one: 1\n", want: `unknown section "splits"`},
+		{file: "emulated: maybe\n", want: "emulated: expected true or false"},
+	}
+	
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "run.yaml")
+		if err := os.WriteFile(path, []byte(tt.file), 0644); err != nil {
+			t.Fatal(err)
+		}
+		opts := submitOptions{Variables: make(map[string]string)}
+		if err := loadSubmitFile(path, &opts); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadSubmitFile(%q) error = %v, want %q", tt.file, err, tt.want)
+		}
+	}
+}
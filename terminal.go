@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	DefaultTerminalWidth  = 80
+	DefaultTerminalHeight = 24
+	// LeaderboardChromeLines is the number of lines around the leaderboard
+	// table (title, link, headers, page footer, and controls).
+	LeaderboardChromeLines = 12
+	MinLeaderboardPageSize = 5
+)
+
+var terminalCache struct {
+	sync.Mutex
+	cols, rows int
+	ok         bool
+	valid      bool
+}
+
+// detectedTerminalSize returns the size of the terminal on stdout, or the
+// COLUMNS/LINES environment variables when stdout is not a terminal. ok is
+// false when neither is available. The kernel size is cached until the
+// next SIGWINCH.
+func detectedTerminalSize() (cols, rows int, ok bool) {
+	terminalCache.Lock()
+	if !terminalCache.valid {
+		terminalCache.cols, terminalCache.rows, terminalCache.ok = terminalSize()
+		terminalCache.valid = true
+	}
+	cols, rows, ok = terminalCache.cols, terminalCache.rows, terminalCache.ok
+	terminalCache.Unlock()
+	
+	if ok {
+		return cols, rows, true
+	}
+	
+	cols, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	rows, _ = strconv.Atoi(os.Getenv("LINES"))
+	return cols, rows, cols > 0 || rows > 0
+}
+
+// invalidateTerminalSize drops the cached size so the next render
+// measures the terminal again.
+func invalidateTerminalSize() {
+	terminalCache.Lock()
+	terminalCache.valid = false
+	terminalCache.Unlock()
+}
+
+// terminalDimensions returns the terminal size, falling back to 80x24 for
+// whatever could not be detected.
+func terminalDimensions() (int, int) {
+	cols, rows, _ := detectedTerminalSize()
+	if cols <= 0 {
+		cols = DefaultTerminalWidth
+	}
+	if rows <= 0 {
+		rows = DefaultTerminalHeight
+	}
+	return cols, rows
+}
+
+// leaderboardPageSize returns the number of runs per leaderboard page: the
+// page_size setting when set, otherwise as many rows as fit in the terminal.
+func leaderboardPageSize() int {
+	if size := settings.Int("page_size"); size > 0 {
+		return size
+	}
+	
+	_, rows, ok := detectedTerminalSize()
+	if !ok || rows <= 0 {
+		return LeaderboardPageSize
+	}
+	if size := rows - LeaderboardChromeLines; size > MinLeaderboardPageSize {
+		return size
+	}
+	return MinLeaderboardPageSize
+}
+
+// displayWidth returns the number of terminal cells s occupies, ignoring
+// ANSI color sequences and counting emoji and East Asian wide characters
+// as two cells.
+func displayWidth(s string) int {
+	if strings.IndexByte(s, '\x1b') >= 0 {
+		s = ansiSequence.ReplaceAllString(s, "")
+	}
+	
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == 0x200D || (r >= 0xFE00 && r <= 0xFE0F) || (r >= 0x1F3FB && r <= 0x1F3FF):
+		return 0 // joiners, variation selectors, skin tone modifiers
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.IsControl(r):
+		return 0
+	case isWideRune(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// wideRanges lists East Asian wide/fullwidth blocks and characters that
+// terminals render as two-cell emoji.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE30, 0xFE4F}, {0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A}, {0x1F1E6, 0x1F1FF}, {0x1F200, 0x1F251}, {0x1F300, 0x1F64F},
+	{0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x3FFFD},
+}
+
+func isWideRune(r rune) bool {
+	if r < 0x1100 {
+		return false
+	}
+	for _, wide := range wideRanges {
+		if r < wide[0] {
+			return false
+		}
+		if r <= wide[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// padRight pads s with spaces to width terminal cells.
+func padRight(s string, width int) string {
+	if gap := width - displayWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// pageOutput prints lines one screenful at a time, pausing between pages.
+func pageOutput(lines []string) {
+	_, rows := terminalDimensions()
+	pageSize := rows - 2
+	if pageSize < 5 {
+		pageSize = 5
+	}
+	
+	for start := 0; start < len(lines); start += pageSize {
+		end := start + pageSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		
+		for _, line := range lines[start:end] {
+			fmt.Println(line)
+		}
+		
+		if end == len(lines) {
+			return
+		}
+		
+		input := getUserInput(fmt.Sprintf("-- More (%d%%) -- Enter for next page, 'q' to stop: ", end*100/len(lines)))
+		if choice := parseUserInput(input); choice.IsQuit || choice.IsBack {
+			return
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !darwin
+
+package main
+
+import "errors"
+
+func terminalSize() (cols, rows int, ok bool) {
+	return 0, 0, false
+}
+
+func watchTerminalResize() {}
+
+func enableRawMode(fd uintptr) (func(), error) {
+	return nil, errors.New("raw mode is not supported on this platform")
+}
+
+func disableEcho(fd uintptr) (func(), error) {
+	return nil, errors.New("echo control is not supported on this platform")
+}
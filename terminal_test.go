@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{input: "", want: 0},
+		{input: "Cheese", want: 6},
+		{input: "é", want: 1},
+		{input: "e\u0301", want: 1},
+		{input: "日本語", want: 6},
+		{input: "Ａ", want: 2},
+		{input: "🥇", want: 2},
+		{input: "👍🏽", want: 2},
+		{input: "🥇 1st", want: 6},
+		{input: "\x1b[1mbold\x1b[0m", want: 4},
+		{input: "\t", want: 0},
+	}
+	
+	for _, tt := range tests {
+		if got := displayWidth(tt.input); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLeaderboardPageSize(t *testing.T) {
+	tests := []struct {
+		rows     int
+		pageSize string
+		want     int
+	}{
+		{rows: 0, want: LeaderboardPageSize},
+		{rows: 50, want: 50 - LeaderboardChromeLines},
+		{rows: LeaderboardChromeLines + 2, want: MinLeaderboardPageSize},
+		{rows: 50, pageSize: "7", want: 7},
+		{rows: 0, pageSize: "40", want: 40},
+	}
+	
+	for _, tt := range tests {
+		plainOutput(t, 80, tt.rows)
+		values := make(map[string]string)
+		if tt.pageSize != "" {
+			values["page_size"] = tt.pageSize
+		}
+		saved := settings
+		var err error
+		if settings, err = resolveSettings(Config{Values: values}, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		if got := leaderboardPageSize(); got != tt.want {
+			t.Errorf("leaderboardPageSize() with %d rows and page_size %q = %d, want %d", tt.rows, tt.pageSize, got, tt.want)
+		}
+		settings = saved
+	}
+}
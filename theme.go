@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultThemeName = "default"
+	NoColorEnvVar    = "NO_COLOR"
+)
+
+// Theme is the palette and symbol set used for all terminal output.
+type Theme struct {
+	Colors Colors
+	// ASCII replaces emoji and box-drawing characters with plain text.
+	ASCII bool
+	// Interactive is false when stdout is not a terminal; progress
+	// messages are suppressed so logs and pipes stay clean.
+	Interactive bool
+}
+
+var theme = Theme{Colors: DefaultColors, Interactive: true}
+
+// BuiltinThemes are the themes available without any configuration. Each
+// maps color roles to color specs (see parseColorSpec); roles left out
+// keep the default palette.
+var BuiltinThemes = map[string]map[string]string{
+	DefaultThemeName: {},
+	"none": {
+		"gold": "", "silver": "", "bronze": "", "green": "", "red": "", "blue": "",
+		"bold": "", "underline": "", "highlight": "",
+	},
+	"solarized": {
+		"gold": "136", "silver": "245", "bronze": "166",
+		"green": "64", "red": "160", "blue": "33",
+	},
+	"dracula": {
+		"gold": "#f1fa8c", "silver": "#f8f8f2", "bronze": "#ffb86c",
+		"green": "#50fa7b", "red": "#ff5555", "blue": "#bd93f9",
+	},
+}
+
+var namedColors = map[string]int{
+	"black": 30, "red": 31, "green": 32, "yellow": 33,
+	"blue": 34, "magenta": 35, "cyan": 36, "white": 37,
+}
+
+var textAttributes = map[string]int{
+	"bold": 1, "dim": 2, "italic": 3, "underline": 4, "reverse": 7,
+}
+
+// asciiReplacer maps the symbols used in static text (help, lists) to
+// plain ASCII.
+var asciiReplacer = strings.NewReplacer(
+	"•", "*",
+	"→", "->",
+	"─", "-",
+	"—", "-",
+	"│", "|",
+	"●", "*",
+)
+
+// configureTheme selects the palette and symbol set from settings.
+// Colors are disabled when NO_COLOR is set or stdout is not a terminal.
+func configureTheme(cfg Config) error {
+	theme.Interactive = stdoutIsTerminal()
+	theme.ASCII = settings.Bool("ascii")
+	if theme.ASCII {
+		EmptyValuePlaceholder = "-"
+	}
+	
+	colors, err := resolveTheme(settings.String("theme"), cfg.Themes)
+	if err != nil {
+		return err
+	}
+	
+	if os.Getenv(NoColorEnvVar) != "" || globalOptions.NoColor || !theme.Interactive {
+		colors = Colors{}
+	}
+	theme.Colors = colors
+	return nil
+}
+
+// resolveTheme builds the palette for a built-in or user-defined theme.
+// A user theme may set "base" to start from another theme.
+func resolveTheme(name string, custom map[string]map[string]string) (Colors, error) {
+	return resolveThemeDepth(name, custom, 0)
+}
+
+func resolveThemeDepth(name string, custom map[string]map[string]string, depth int) (Colors, error) {
+	if depth > len(custom)+1 {
+		return Colors{}, fmt.Errorf("theme %q has a circular base", name)
+	}
+	
+	spec, exists := custom[name]
+	if !exists {
+		spec, exists = BuiltinThemes[name]
+	}
+	if !exists {
+		return Colors{}, fmt.Errorf("unknown theme %q", name)
+	}
+	
+	colors := DefaultColors
+	if base := spec["base"]; base != "" && base != name {
+		var err error
+		if colors, err = resolveThemeDepth(base, custom, depth+1); err != nil {
+			return Colors{}, err
+		}
+	}
+	
+	roles := map[string]*string{
+		"gold": &colors.Gold, "silver": &colors.Silver, "bronze": &colors.Bronze,
+		"green": &colors.Green, "red": &colors.Red, "blue": &colors.Blue,
+		"bold": &colors.Bold, "underline": &colors.Underline, "highlight": &colors.Highlight,
+	}
+	for role, value := range spec {
+		if role == "base" {
+			continue
+		}
+		target, known := roles[role]
+		if !known {
+			return Colors{}, fmt.Errorf("theme %q: unknown color role %q", name, role)
+		}
+		sequence, err := parseColorSpec(value)
+		if err != nil {
+			return Colors{}, fmt.Errorf("theme %q: %s: %w", name, role, err)
+		}
+		*target = sequence
+	}
+	
+	colors.Reset = DefaultColors.Reset
+	if colors == (Colors{Reset: DefaultColors.Reset}) {
+		colors.Reset = ""
+	}
+	return colors, nil
+}
+
+// parseColorSpec turns a color spec into an ANSI escape sequence. A spec
+// is a space-separated list of attributes (bold, dim, italic, underline,
+// reverse), basic color names (red, bright-blue), 256-color indexes
+// (0-255), or truecolor hex values (#rrggbb). An empty spec means no
+// styling.
+func parseColorSpec(spec string) (string, error) {
+	var codes []string
+	
+	for _, part := range strings.Fields(strings.ToLower(spec)) {
+		if code, ok := textAttributes[part]; ok {
+			codes = append(codes, strconv.Itoa(code))
+			continue
+		}
+		
+		if code, ok := namedColors[strings.TrimPrefix(part, "bright-")]; ok {
+			if strings.HasPrefix(part, "bright-") {
+				code += 60
+			}
+			codes = append(codes, strconv.Itoa(code))
+			continue
+		}
+		
+		if index, err := strconv.Atoi(part); err == nil {
+			if index < 0 || index > 255 {
+				return "", fmt.Errorf("color index %d out of range 0-255", index)
+			}
+			codes = append(codes, fmt.Sprintf("38;5;%d", index))
+			continue
+		}
+		
+		if strings.HasPrefix(part, "#") && len(part) == 7 {
+			rgb, err := strconv.ParseUint(part[1:], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid hex color %q", part)
+			}
+			codes = append(codes, fmt.Sprintf("38;2;%d;%d;%d", rgb>>16, (rgb>>8)&0xff, rgb&0xff))
+			continue
+		}
+		
+		return "", fmt.Errorf("invalid color %q", part)
+	}
+	
+	if len(codes) == 0 {
+		return "", nil
+	}
+	return "\033[" + strings.Join(codes, ";") + "m", nil
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// icon returns an emoji followed by a space, or nothing in ASCII mode.
+// Emoji with a variation selector get a second space, since many
+// terminals draw them one cell wide but advance the cursor by two.
+func icon(emoji string) string {
+	if theme.ASCII {
+		return ""
+	}
+	if strings.HasSuffix(emoji, "️") {
+		return emoji + "  "
+	}
+	return emoji + " "
+}
+
+// symbol returns the emoji or its plain-text replacement in ASCII mode.
+func symbol(emoji, ascii string) string {
+	if theme.ASCII {
+		return ascii
+	}
+	return emoji
+}
+
+func checkMark(value bool) string {
+	if value {
+		return symbol("✅", "Y")
+	}
+	return symbol("❌", "N")
+}
+
+// plainText applies the ASCII replacements to static text in ASCII mode.
+func plainText(s string) string {
+	if theme.ASCII {
+		return asciiReplacer.Replace(s)
+	}
+	return s
+}
+
+func goodbye() string {
+	return "Goodbye!" + symbol(" 👋", "")
+}
+
+var progressWidth int
+
+// showProgress prints a transient status line that clearProgress erases.
+// Nothing is printed when stdout is not a terminal.
+func showProgress(emoji, format string, args ...interface{}) {
+	if !theme.Interactive {
+		return
+	}
+	message := icon(emoji) + fmt.Sprintf(format, args...)
+	progressWidth = displayWidth(message)
+	fmt.Print(message)
+}
+
+func clearProgress() {
+	if !theme.Interactive || progressWidth == 0 {
+		return
+	}
+	fmt.Print("\r" + strings.Repeat(" ", progressWidth) + "\r")
+	progressWidth = 0
+}
+
+// horizontalRule returns a separator line width cells wide.
+func horizontalRule(width int) string {
+	return strings.Repeat(symbol("─", "-"), width)
+}
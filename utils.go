@@ -2,49 +2,59 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
 const (
-	APIBase               = "https://www.speedrun.com/api/v1"
-	UserAgent             = "speedrun-cli/1.0"
-	DefaultTimeout        = 30
-	MaxRetries            = 3
-	BackoffBase           = 2
-	MaxRankWithMedal      = 3
-	DefaultColumnWidth    = 20
-	CommentMaxWidth       = 25
-	EmptyValuePlaceholder = "—"
+	DefaultAPIBase            = "https://www.speedrun.com/api/v1"
+	UserAgent                 = "speedrun-cli/1.0"
+	DefaultTimeout            = 30
+	MaxRetries                = 3
+	NotificationCheckInterval = 300
+	BackoffBase               = 2
+	LeaderboardFetchWorkers   = 4
+	MaxRankWithMedal          = 3
+	LeaderboardPageSize       = 25
+	RunHistoryPageSize        = 200
+	MaxProfileRuns            = 1000
+	MaxGameStatsRuns          = 5000
+	DefaultColumnWidth        = 20
+	CommentMaxWidth           = 25
 )
 
+// EmptyValuePlaceholder is shown for missing values; ASCII mode swaps it
+// for a plain hyphen.
+var EmptyValuePlaceholder = "—"
+
 type Colors struct {
-	Gold   string
-	Silver string
-	Bronze string
-	Reset  string
-	Green  string
-	Red    string
-	Blue   string
+	Gold      string
+	Silver    string
+	Bronze    string
+	Reset     string
+	Green     string
+	Red       string
+	Blue      string
+	Bold      string
+	Underline string
+	Highlight string
 }
 
 var DefaultColors = Colors{
-	Gold:   "\033[33m",
-	Silver: "\033[37m",
-	Bronze: "\033[31m",
-	Reset:  "\033[0m",
-	Green:  "\033[32m",
-	Red:    "\033[31m",
-	Blue:   "\033[34m",
-}
-
-func debugLog(format string, args ...interface{}) {
-	if os.Getenv("SPEEDRUN_DEBUG") != "" {
-		log.Printf("[DEBUG] "+format, args...)
-	}
+	Gold:      "\033[33m",
+	Silver:    "\033[37m",
+	Bronze:    "\033[31m",
+	Reset:     "\033[0m",
+	Green:     "\033[32m",
+	Red:       "\033[31m",
+	Blue:      "\033[34m",
+	Bold:      "\033[1m",
+	Underline: "\033[4m",
+	Highlight: "\033[7m",
 }
 
 func truncateString(s string, maxLen int) string {
@@ -52,14 +62,27 @@ func truncateString(s string, maxLen int) string {
 		return EmptyValuePlaceholder
 	}
 	
-	runes := []rune(s)
-	if len(runes) <= maxLen {
+	if displayWidth(s) <= maxLen {
 		return s
 	}
 	if maxLen <= 3 {
-		return string(runes[:maxLen])
+		return truncateToWidth(s, maxLen)
 	}
-	return string(runes[:maxLen-3]) + "..."
+	return truncateToWidth(s, maxLen-3) + "..."
+}
+
+// truncateToWidth cuts s to at most width terminal cells without
+// splitting a wide character.
+func truncateToWidth(s string, width int) string {
+	used := 0
+	for i, r := range s {
+		w := runeWidth(r)
+		if used+w > width {
+			return s[:i]
+		}
+		used += w
+	}
+	return s
 }
 
 func cleanComment(comment string) string {
@@ -83,6 +106,20 @@ func cleanComment(comment string) string {
 	return result
 }
 
+// preferredTimes orders a run's times so the timing_method setting, when
+// set, is tried before the primary time.
+func preferredTimes(primary, realtime, realtimeNoLoads, ingame string) []string {
+	switch settings.String("timing_method") {
+	case "realtime":
+		return []string{realtime, primary, realtimeNoLoads, ingame}
+	case "realtime_noloads":
+		return []string{realtimeNoLoads, primary, realtime, ingame}
+	case "ingame":
+		return []string{ingame, primary, realtime, realtimeNoLoads}
+	}
+	return []string{primary, realtime, realtimeNoLoads, ingame}
+}
+
 func formatTime(timeStr string) string {
 	if timeStr == "" || timeStr == "null" {
 		return EmptyValuePlaceholder
@@ -100,6 +137,10 @@ func formatTime(timeStr string) string {
 }
 
 func parsePTFormat(ptTime string) string {
+	return formatSeconds(ptSeconds(ptTime))
+}
+
+func ptSeconds(ptTime string) float64 {
 	ptTime = strings.TrimPrefix(ptTime, "PT")
 	
 	var hours, minutes float64
@@ -125,8 +166,7 @@ func parsePTFormat(ptTime string) string {
 		}
 	}
 	
-	totalSeconds := hours*3600 + minutes*60 + seconds
-	return formatSeconds(totalSeconds)
+	return hours*3600 + minutes*60 + seconds
 }
 
 func formatSeconds(totalSeconds float64) string {
@@ -152,13 +192,84 @@ func formatSeconds(totalSeconds float64) string {
 	return fmt.Sprintf("%.3f", seconds)
 }
 
+// parseTimeInput accepts "1:23:45.678", "23:45.6", or plain seconds.
+func parseTimeInput(input string) (float64, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, fmt.Errorf("empty time")
+	}
+	
+	parts := strings.Split(input, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time %q", input)
+	}
+	
+	var total float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid time %q", input)
+		}
+		if i < len(parts)-1 && value != float64(int(value)) {
+			return 0, fmt.Errorf("invalid time %q", input)
+		}
+		total = total*60 + value
+	}
+	
+	if total <= 0 {
+		return 0, fmt.Errorf("time must be greater than zero")
+	}
+	return total, nil
+}
+
+func timingMethodName(method string) string {
+	switch method {
+	case "realtime":
+		return "Real time (RTA)"
+	case "realtime_noloads":
+		return "Load-removed time (LRT)"
+	case "ingame":
+		return "In-game time (IGT)"
+	default:
+		return method
+	}
+}
+
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return EmptyValuePlaceholder
+	}
+	
+	age := time.Since(t)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	case age < 60*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	default:
+		return fmt.Sprintf("%dmo ago", int(age.Hours()/(24*30)))
+	}
+}
+
+// formatBytes prints a size in B, KB, or MB.
+func formatBytes(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
 
 func calculateDynamicWidth(content []string, maxWidth int) int {
 	width := 0
 	for _, item := range content {
-		runes := []rune(item)
-		if len(runes) > width {
-			width = len(runes)
+		if w := displayWidth(item); w > width {
+			width = w
 		}
 	}
 	if width > maxWidth {
@@ -168,4 +279,27 @@ func calculateDynamicWidth(content []string, maxWidth int) int {
 		return 5
 	}
 	return width
-}
\ No newline at end of file
+}
+
+// writeFileAtomic replaces path in one step, so readers never see a
+// half-written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
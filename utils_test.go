@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestParseTimeInput(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{input: "1:23:45.678", want: 5025.678},
+		{input: "23:45.6", want: 1425.6},
+		{input: "90", want: 90},
+		{input: " 0:59 ", want: 59},
+		{input: "1:00:00", want: 3600},
+		{input: "", wantErr: true},
+		{input: "1:2:3:4", wantErr: true},
+		{input: "1.5:00", wantErr: true},
+		{input: "-5", wantErr: true},
+		{input: "0", wantErr: true},
+		{input: "0:00", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "1::30", wantErr: true},
+	}
+	
+	for _, tt := range tests {
+		got, err := parseTimeInput(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseTimeInput(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTimeInput(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("parseTimeInput(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}